@@ -0,0 +1,100 @@
+package lucide
+
+import (
+	"regexp"
+	"strconv"
+	"sync/atomic"
+)
+
+// animations maps the values of the Animation option to their default
+// duration in seconds.
+var animations = map[string]float64{
+	"spin":  1,
+	"pulse": 2,
+	"draw":  1.5,
+}
+
+// drawLength is the dash length used by the draw animation. It exceeds the
+// length of the individual shapes of Lucide icons on the 24x24 grid, so
+// each shape is drawn as a single dash.
+const drawLength = 100
+
+// motionIDCounter generates unique class names for animated icons.
+var motionIDCounter atomic.Uint64
+
+// cssIdentPattern matches id attributes that are safe to use as a CSS class
+// name without escaping.
+var cssIdentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// motionID returns the class name identifying an animated icon with the
+// given id attribute, or a generated unique name if the id is not a plain
+// CSS identifier.
+func motionID(base string) string {
+	if cssIdentPattern.MatchString(base) {
+		return base + "-motion"
+	}
+	return "lucide-motion-" + strconv.FormatUint(motionIDCounter.Add(1), 10)
+}
+
+// appendAnimated appends paths animated with SMIL, which needs no external
+// CSS. A still copy of the paths is drawn through <use> instead when the
+// user prefers reduced motion, switched by a <style> carrying opts.Nonce
+// for pages with a Content-Security-Policy.
+func appendAnimated(b []byte, paths string, opts Options) []byte {
+	id := motionID(opts.Attrs["id"])
+	dur := opts.AnimationDuration
+	if dur <= 0 {
+		dur = animations[opts.Animation]
+	}
+
+	b = append(b, `<g class="`...)
+	b = append(b, id...)
+	b = append(b, '"')
+	if opts.Animation == "draw" {
+		b = append(b, ` stroke-dasharray="`...)
+		b = strconv.AppendInt(b, drawLength, 10)
+		b = append(b, `" stroke-dashoffset="0"`...)
+	}
+	b = append(b, '>')
+
+	switch opts.Animation {
+	case "spin":
+		b = append(b, `<animateTransform attributeName="transform" type="rotate" from="0 12 12" to="360 12 12" dur="`...)
+		b = appendNumber(b, dur)
+		b = append(b, `s" repeatCount="indefinite" />`...)
+	case "pulse":
+		b = append(b, `<animate attributeName="opacity" values="1;0.4;1" dur="`...)
+		b = appendNumber(b, dur)
+		b = append(b, `s" repeatCount="indefinite" />`...)
+	case "draw":
+		b = append(b, `<animate attributeName="stroke-dashoffset" from="`...)
+		b = strconv.AppendInt(b, drawLength, 10)
+		b = append(b, `" to="0" dur="`...)
+		b = appendNumber(b, dur)
+		b = append(b, `s" fill="freeze" />`...)
+	}
+
+	b = append(b, `<g id="`...)
+	b = append(b, id...)
+	b = append(b, `-paths">`...)
+	b = append(b, paths...)
+	b = append(b, `</g></g><use href="#`...)
+	b = append(b, id...)
+	b = append(b, `-paths" class="`...)
+	b = append(b, id...)
+	b = append(b, `-still" /><style`...)
+	if opts.Nonce != "" {
+		b = append(b, ` nonce="`...)
+		b = appendEscaped(b, opts.Nonce)
+		b = append(b, '"')
+	}
+	b = append(b, `>.`...)
+	b = append(b, id...)
+	b = append(b, `-still{display:none}@media (prefers-reduced-motion:reduce){.`...)
+	b = append(b, id...)
+	b = append(b, `{display:none}.`...)
+	b = append(b, id...)
+	b = append(b, `-still{display:inline}}</style>`...)
+
+	return b
+}
@@ -0,0 +1,111 @@
+package lucide
+
+import (
+	"errors"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestAnimation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{
+			name: "spin",
+			opts: Options{Animation: "spin", Attrs: map[string]string{"id": "loader"}},
+			want: []string{
+				`<g class="loader-motion"><animateTransform attributeName="transform" type="rotate" from="0 12 12" to="360 12 12" dur="1s" repeatCount="indefinite" />`,
+				`<g id="loader-motion-paths">` + iconLoaderCircle.paths + `</g></g>`,
+			},
+		},
+		{
+			name: "pulse",
+			opts: Options{Animation: "pulse", AnimationDuration: 0.5, Attrs: map[string]string{"id": "loader"}},
+			want: []string{
+				`<animate attributeName="opacity" values="1;0.4;1" dur="0.5s" repeatCount="indefinite" />`,
+			},
+		},
+		{
+			name: "draw",
+			opts: Options{Animation: "draw", Attrs: map[string]string{"id": "loader"}},
+			want: []string{
+				`<g class="loader-motion" stroke-dasharray="100" stroke-dashoffset="0">`,
+				`<animate attributeName="stroke-dashoffset" from="100" to="0" dur="1.5s" fill="freeze" />`,
+			},
+		},
+		{
+			name: "reduced motion",
+			opts: Options{Animation: "spin", Attrs: map[string]string{"id": "loader"}},
+			want: []string{
+				`<use href="#loader-motion-paths" class="loader-motion-still" />`,
+				`<style>.loader-motion-still{display:none}@media (prefers-reduced-motion:reduce){.loader-motion{display:none}.loader-motion-still{display:inline}}</style>`,
+			},
+		},
+		{
+			name: "nonce",
+			opts: Options{Animation: "spin", Nonce: `a"b`},
+			want: []string{`<style nonce="a&#34;b">`},
+		},
+		{
+			name: "rotated",
+			opts: Options{Animation: "spin", Rotate: 90, Attrs: map[string]string{"id": "loader"}},
+			want: []string{`<g transform="rotate(90 12 12)"><g class="loader-motion">`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render("loader-circle", tt.opts)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("Render() = %v, want to contain %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnimationUniqueIDs(t *testing.T) {
+	opts := Options{Animation: "spin"}
+	first, _ := Render("loader-circle", opts)
+	second, _ := Render("loader-circle", opts)
+	if first == second {
+		t.Errorf("Render() = %v twice, want unique ids", first)
+	}
+	if !strings.Contains(string(first), `class="lucide-motion-`) {
+		t.Errorf("Render() = %v, want a generated class", first)
+	}
+
+	fn := FuncMap(&Config{Cache: NewCache(64)})["lucide"].(func(any, ...any) (template.HTML, error))
+	first, _ = fn("loader-circle", "animation", "spin")
+	second, _ = fn("loader-circle", "animation", "spin")
+	if first == second {
+		t.Errorf("cached lucide() = %v twice, want unique ids", first)
+	}
+}
+
+func TestAnimationOptions(t *testing.T) {
+	got := Icon("loader-circle", "animation", "spin", "animationDuration", "2", "nonce", "abc")
+	for _, want := range []string{`dur="2s"`, `<style nonce="abc">`} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Icon() = %v, want to contain %v", got, want)
+		}
+	}
+
+	for _, opts := range []Options{
+		{Animation: "wobble"},
+		{Animation: "spin", AnimationDuration: -1},
+	} {
+		_, err := Render("loader-circle", opts)
+		var invalid *ErrInvalidOption
+		if !errors.As(err, &invalid) {
+			t.Errorf("Render(%+v) error = %v, want *ErrInvalidOption", opts, err)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package lucide
+
+import (
+	"container/list"
+	"html/template"
+	"sync"
+	"sync/atomic"
+)
+
+// Cache is a bounded, concurrency-safe cache of rendered icons, keyed by
+// icon and normalized Options. When full, the least recently used entry is
+// evicted.
+//
+// Icons with a Label but no id attribute are never cached, since each of
+// them needs a unique <title> id.
+//
+// Usage:
+//
+//	// Cache Icon, Render and the individual icon functions
+//	lucide.SetCache(lucide.NewCache(512))
+//
+//	// Or only the template function of one FuncMap
+//	tmpl.Funcs(lucide.FuncMap(&lucide.Config{
+//	    Cache: lucide.NewCache(512),
+//	}))
+type Cache struct {
+	mu        sync.Mutex
+	capacity  int
+	entries   map[cacheKey]*list.Element
+	lru       *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// CacheStats reports the usage of a Cache.
+type CacheStats struct {
+	// Hits is the number of renders served from the cache
+	Hits uint64
+
+	// Misses is the number of renders that were not cached
+	Misses uint64
+
+	// Evictions is the number of entries removed to make room
+	Evictions uint64
+
+	// Len is the number of cached entries
+	Len int
+
+	// Capacity is the maximum number of cached entries
+	Capacity int
+}
+
+// cacheEntry is an element of Cache.lru.
+type cacheEntry struct {
+	key cacheKey
+	svg template.HTML
+}
+
+// cacheKey identifies a rendered icon. It mirrors Options with defaults
+// applied, so equivalent options share an entry, and with Attrs flattened
+// into a comparable string.
+type cacheKey struct {
+	icon                *icon
+	size                int
+	width               string
+	height              string
+	omitSize            bool
+	color               string
+	strokeWidth         float64
+	absoluteStrokeWidth bool
+	fill                string
+	filled              bool
+	strokeLinecap       string
+	strokeLinejoin      string
+	class               string
+	attrs               string
+	label               string
+	rotate              float64
+	flipX               bool
+	flipY               bool
+	rtl                 bool
+	animation           string
+	animationDuration   float64
+	nonce               string
+}
+
+// newCacheKey returns the cache key for rendering ic with opts.
+func newCacheKey(ic *icon, opts Options) cacheKey {
+	if opts.Color == "" {
+		opts.Color = "currentColor"
+	}
+	if opts.StrokeWidth == 0 {
+		opts.StrokeWidth = 2
+	}
+
+	return cacheKey{
+		icon:                ic,
+		size:                opts.size(),
+		width:               opts.Width,
+		height:              opts.Height,
+		omitSize:            opts.OmitSize,
+		color:               opts.Color,
+		strokeWidth:         opts.StrokeWidth,
+		absoluteStrokeWidth: opts.AbsoluteStrokeWidth,
+		fill:                opts.Fill,
+		filled:              opts.Filled,
+		strokeLinecap:       opts.StrokeLinecap,
+		strokeLinejoin:      opts.StrokeLinejoin,
+		class:               opts.Class,
+		attrs:               string(appendAttrs(nil, opts.Attrs)),
+		label:               opts.Label,
+		rotate:              opts.rotation(),
+		flipX:               opts.FlipX,
+		flipY:               opts.FlipY,
+		rtl:                 opts.RTL,
+		animation:           opts.Animation,
+		animationDuration:   opts.AnimationDuration,
+		nonce:               opts.Nonce,
+	}
+}
+
+// cacheable reports whether an icon rendered with opts can be cached.
+// Labelled and animated icons contain generated ids, which must be unique,
+// unless they are derived from the id attribute.
+func cacheable(opts Options) bool {
+	id := opts.Attrs["id"]
+	if opts.Label != "" && id == "" {
+		return false
+	}
+	if opts.Animation != "" && !cssIdentPattern.MatchString(id) {
+		return false
+	}
+	return true
+}
+
+// globalCache is the cache used by Icon, Render and the individual icon
+// functions. It is nil unless set with SetCache.
+var globalCache atomic.Pointer[Cache]
+
+// SetCache sets the cache used by Icon, Render and the individual icon
+// functions. Pass nil to disable caching, which is the default.
+func SetCache(c *Cache) {
+	globalCache.Store(c)
+}
+
+// NewCache creates a cache holding up to capacity rendered icons.
+// A capacity below one is treated as one.
+func NewCache(capacity int) *Cache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Cache{
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element, capacity),
+		lru:      list.New(),
+	}
+}
+
+// Stats returns the current cache statistics.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Len:       c.lru.Len(),
+		Capacity:  c.capacity,
+	}
+}
+
+// Clear removes all entries and resets the statistics.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.lru.Init()
+	c.hits, c.misses, c.evictions = 0, 0, 0
+}
+
+// get returns the cached icon for key and marks it as recently used.
+func (c *Cache) get(key cacheKey) (template.HTML, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return template.HTML(""), false
+	}
+
+	c.hits++
+	c.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry).svg, true
+}
+
+// add caches svg for key, evicting the least recently used entry if the
+// cache is full.
+func (c *Cache) add(key cacheKey, svg template.HTML) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	if c.lru.Len() >= c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.evictions++
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, svg: svg})
+}
+
+// renderCached renders ic with opts, using c if it is not nil.
+func renderCached(c *Cache, ic *icon, opts Options) template.HTML {
+	opts = ic.directed(opts)
+
+	if c == nil || !cacheable(opts) {
+		return buildSVG(ic.paths, opts)
+	}
+
+	key := newCacheKey(ic, opts)
+	if svg, ok := c.get(key); ok {
+		return svg
+	}
+
+	svg := buildSVG(ic.paths, opts)
+	c.add(key, svg)
+	return svg
+}
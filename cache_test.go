@@ -0,0 +1,183 @@
+package lucide
+
+import (
+	"bytes"
+	"html/template"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+	ic, _ := lookupIcon("circle-x")
+
+	first := renderCached(c, ic, Options{Size: 32})
+	second := renderCached(c, ic, Options{Size: 32})
+	if first != second {
+		t.Errorf("cached render = %v, want %v", second, first)
+	}
+
+	// Defaults are normalized, so these share an entry.
+	renderCached(c, ic, Options{})
+	renderCached(c, ic, Options{Size: 24, StrokeWidth: 2, Color: "currentColor"})
+
+	stats := c.Stats()
+	if stats.Hits != 2 || stats.Misses != 2 || stats.Len != 2 || stats.Capacity != 2 {
+		t.Errorf("Stats() = %+v, want 2 hits, 2 misses, 2 entries", stats)
+	}
+
+	renderCached(c, ic, Options{Size: 48})
+	if stats := c.Stats(); stats.Evictions != 1 || stats.Len != 2 {
+		t.Errorf("Stats() = %+v, want 1 eviction, 2 entries", stats)
+	}
+
+	c.Clear()
+	if stats := c.Stats(); stats != (CacheStats{Capacity: 2}) {
+		t.Errorf("Stats() after Clear = %+v, want empty", stats)
+	}
+}
+
+func TestCacheKeyAttrs(t *testing.T) {
+	c := NewCache(8)
+	ic, _ := lookupIcon("circle-x")
+
+	a := renderCached(c, ic, Options{Attrs: map[string]string{"id": "a"}})
+	b := renderCached(c, ic, Options{Attrs: map[string]string{"id": "b"}})
+	if a == b {
+		t.Errorf("renders with different attrs share a cache entry")
+	}
+	if stats := c.Stats(); stats.Misses != 2 {
+		t.Errorf("Stats() = %+v, want 2 misses", stats)
+	}
+}
+
+func TestCacheSkipsGeneratedTitleIDs(t *testing.T) {
+	c := NewCache(8)
+	ic, _ := lookupIcon("circle-x")
+
+	a := renderCached(c, ic, Options{Label: "Close"})
+	b := renderCached(c, ic, Options{Label: "Close"})
+	if a == b {
+		t.Errorf("labelled renders share a title id: %v", a)
+	}
+	if stats := c.Stats(); stats.Len != 0 {
+		t.Errorf("Stats() = %+v, want no entries", stats)
+	}
+
+	renderCached(c, ic, Options{Label: "Close", Attrs: map[string]string{"id": "close"}})
+	renderCached(c, ic, Options{Label: "Close", Attrs: map[string]string{"id": "close"}})
+	if stats := c.Stats(); stats.Hits != 1 {
+		t.Errorf("Stats() = %+v, want 1 hit for fixed id", stats)
+	}
+}
+
+// TestCacheKeyCoversOptions guards against adding an Options field without
+// adding it to cacheKey, which would make different renders share an entry.
+func TestCacheKeyCoversOptions(t *testing.T) {
+	key := reflect.TypeOf(cacheKey{})
+	opts := reflect.TypeOf(Options{})
+
+	for i := range opts.NumField() {
+		name := opts.Field(i).Name
+		if _, ok := key.FieldByNameFunc(func(s string) bool { return strings.EqualFold(s, name) }); !ok {
+			t.Errorf("cacheKey is missing Options.%s", name)
+		}
+	}
+}
+
+func TestSetCache(t *testing.T) {
+	c := NewCache(8)
+	SetCache(c)
+	defer SetCache(nil)
+
+	CircleX()
+	CircleX()
+	Icon("circle-x")
+	if _, err := Render("circle-x"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if stats := c.Stats(); stats.Hits != 3 || stats.Misses != 1 {
+		t.Errorf("Stats() = %+v, want 3 hits, 1 miss", stats)
+	}
+}
+
+func TestFuncMapCache(t *testing.T) {
+	c := NewCache(8)
+	tmpl := template.Must(template.New("test").Funcs(FuncMap(&Config{Cache: c})).Parse(
+		`{{ range . }}{{ lucide "menu" (dict "size" 16) }}{{ end }}`,
+	))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, []int{1, 2, 3}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if stats := c.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Stats() = %+v, want 2 hits, 1 miss", stats)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(4)
+	ic, _ := lookupIcon("circle-x")
+	want := buildSVG(ic.paths, Options{Size: 1})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				got := renderCached(c, ic, Options{Size: 1 + (i+j)%8})
+				if (i+j)%8 == 0 && got != want {
+					t.Errorf("renderCached() = %v, want %v", got, want)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats := c.Stats(); stats.Hits+stats.Misses != 800 || stats.Len > 4 {
+		t.Errorf("Stats() = %+v, want 800 lookups and at most 4 entries", stats)
+	}
+}
+
+func BenchmarkIconUncached(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = CircleX(benchOptions)
+	}
+}
+
+func BenchmarkIconCached(b *testing.B) {
+	SetCache(NewCache(64))
+	defer SetCache(nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = CircleX(benchOptions)
+	}
+}
+
+func BenchmarkFuncMapUncached(b *testing.B) {
+	fn := FuncMap()["lucide"].(func(any, ...any) (template.HTML, error))
+	opts := map[string]any{"size": 32, "class": "icon"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = fn("circle-x", opts)
+	}
+}
+
+func BenchmarkFuncMapCached(b *testing.B) {
+	fn := FuncMap(&Config{Cache: NewCache(64)})["lucide"].(func(any, ...any) (template.HTML, error))
+	opts := map[string]any{"size": 32, "class": "icon"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = fn("circle-x", opts)
+	}
+}
@@ -0,0 +1,99 @@
+package lucide
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// hexColorPattern matches #rgb, #rgba, #rrggbb and #rrggbbaa.
+	hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-f]{3,4}|[0-9a-f]{6}|[0-9a-f]{8})$`)
+
+	// colorFuncPattern matches the CSS color functions and captures their
+	// arguments.
+	colorFuncPattern = regexp.MustCompile(`^(?:rgba?|hsla?|hwb|lab|lch|oklab|oklch)\((.*)\)$`)
+
+	// colorArgsPattern matches color function arguments limited to numbers,
+	// units, keywords and separators.
+	colorArgsPattern = regexp.MustCompile(`^[-+0-9a-z.%,/ ]*$`)
+
+	// colorArgVarPattern matches a var(--name) color function argument, as
+	// in hsl(var(--primary)), with an optional fallback without separators.
+	colorArgVarPattern = regexp.MustCompile(`var\(\s*--[a-z0-9_-]+\s*(?:,[-+0-9a-z.% ]*)?\)`)
+
+	// varPattern matches var(--name) with an optional fallback.
+	varPattern = regexp.MustCompile(`^var\(\s*--[a-z0-9_-]+\s*(?:,\s*(.+?)\s*)?\)$`)
+)
+
+// colorKeywords are the paint keywords accepted in addition to named colors.
+var colorKeywords = map[string]bool{
+	"currentcolor": true,
+	"transparent":  true,
+	"none":         true,
+	"inherit":      true,
+}
+
+// isCSSColor reports whether s is a named color, hex color, color function,
+// var() reference or one of currentColor, transparent, none and inherit.
+func isCSSColor(s string) bool {
+	if s == "currentColor" {
+		return true
+	}
+
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case s == "":
+		return false
+	case colorKeywords[s], namedColors[s]:
+		return true
+	case strings.HasPrefix(s, "#"):
+		return hexColorPattern.MatchString(s)
+	case strings.HasPrefix(s, "var("):
+		m := varPattern.FindStringSubmatch(s)
+		return m != nil && (m[1] == "" || isCSSColor(m[1]))
+	default:
+		m := colorFuncPattern.FindStringSubmatch(s)
+		return m != nil && colorArgsPattern.MatchString(colorArgVarPattern.ReplaceAllString(m[1], "0"))
+	}
+}
+
+// namedColors are the CSS named colors.
+var namedColors = map[string]bool{
+	"aliceblue": true, "antiquewhite": true, "aqua": true, "aquamarine": true,
+	"azure": true, "beige": true, "bisque": true, "black": true,
+	"blanchedalmond": true, "blue": true, "blueviolet": true, "brown": true,
+	"burlywood": true, "cadetblue": true, "chartreuse": true, "chocolate": true,
+	"coral": true, "cornflowerblue": true, "cornsilk": true, "crimson": true,
+	"cyan": true, "darkblue": true, "darkcyan": true, "darkgoldenrod": true,
+	"darkgray": true, "darkgreen": true, "darkgrey": true, "darkkhaki": true,
+	"darkmagenta": true, "darkolivegreen": true, "darkorange": true, "darkorchid": true,
+	"darkred": true, "darksalmon": true, "darkseagreen": true, "darkslateblue": true,
+	"darkslategray": true, "darkslategrey": true, "darkturquoise": true, "darkviolet": true,
+	"deeppink": true, "deepskyblue": true, "dimgray": true, "dimgrey": true,
+	"dodgerblue": true, "firebrick": true, "floralwhite": true, "forestgreen": true,
+	"fuchsia": true, "gainsboro": true, "ghostwhite": true, "gold": true,
+	"goldenrod": true, "gray": true, "green": true, "greenyellow": true,
+	"grey": true, "honeydew": true, "hotpink": true, "indianred": true,
+	"indigo": true, "ivory": true, "khaki": true, "lavender": true,
+	"lavenderblush": true, "lawngreen": true, "lemonchiffon": true, "lightblue": true,
+	"lightcoral": true, "lightcyan": true, "lightgoldenrodyellow": true, "lightgray": true,
+	"lightgreen": true, "lightgrey": true, "lightpink": true, "lightsalmon": true,
+	"lightseagreen": true, "lightskyblue": true, "lightslategray": true, "lightslategrey": true,
+	"lightsteelblue": true, "lightyellow": true, "lime": true, "limegreen": true,
+	"linen": true, "magenta": true, "maroon": true, "mediumaquamarine": true,
+	"mediumblue": true, "mediumorchid": true, "mediumpurple": true, "mediumseagreen": true,
+	"mediumslateblue": true, "mediumspringgreen": true, "mediumturquoise": true, "mediumvioletred": true,
+	"midnightblue": true, "mintcream": true, "mistyrose": true, "moccasin": true,
+	"navajowhite": true, "navy": true, "oldlace": true, "olive": true,
+	"olivedrab": true, "orange": true, "orangered": true, "orchid": true,
+	"palegoldenrod": true, "palegreen": true, "paleturquoise": true, "palevioletred": true,
+	"papayawhip": true, "peachpuff": true, "peru": true, "pink": true,
+	"plum": true, "powderblue": true, "purple": true, "rebeccapurple": true,
+	"red": true, "rosybrown": true, "royalblue": true, "saddlebrown": true,
+	"salmon": true, "sandybrown": true, "seagreen": true, "seashell": true,
+	"sienna": true, "silver": true, "skyblue": true, "slateblue": true,
+	"slategray": true, "slategrey": true, "snow": true, "springgreen": true,
+	"steelblue": true, "tan": true, "teal": true, "thistle": true,
+	"tomato": true, "turquoise": true, "violet": true, "wheat": true,
+	"white": true, "whitesmoke": true, "yellow": true, "yellowgreen": true,
+}
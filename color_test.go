@@ -0,0 +1,55 @@
+package lucide
+
+import "testing"
+
+func TestIsCSSColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  bool
+	}{
+		{"currentColor", true},
+		{"red", true},
+		{"RebeccaPurple", true},
+		{"transparent", true},
+		{"none", true},
+		{"#fff", true},
+		{"#ffff", true},
+		{"#3b82f6", true},
+		{"#3b82f680", true},
+		{"rgb(59, 130, 246)", true},
+		{"rgba(59,130,246,0.5)", true},
+		{"rgb(59 130 246 / 50%)", true},
+		{"hsl(217deg 91% 60%)", true},
+		{"oklch(0.62 0.19 259.8)", true},
+		{"var(--icon-color)", true},
+		{"var(--icon-color, #fff)", true},
+		{"var( --icon-color , var(--fallback) )", true},
+		{"hsl(var(--primary))", true},
+		{"hsl(var(--primary) / 0.5)", true},
+		{"rgb(var(--r) var(--g) var(--b))", true},
+		{"rgb(var(--r, 255) 0 0)", true},
+		{"", false},
+		{"notacolor", false},
+		{"#ggg", false},
+		{"#12345", false},
+		{"rgb(1,2,3", false},
+		{"url(#gradient)", false},
+		{"expression(alert(1))", false},
+		{"var(--x, javascript:alert(1))", false},
+		{`red" onload="alert(1)`, false},
+		{"red;background:url(x)", false},
+		{"rgb(1,2,3)<script>", false},
+		{"var(--x)}</style><script>", false},
+		{"hsl(var(--x, url(y)))", false},
+		{"rgb(var(--x)) url(y)", false},
+		{"hsl(var(--x)<script>)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			if got := isCSSColor(tt.color); got != tt.want {
+				t.Errorf("isCSSColor(%q) = %v, want %v", tt.color, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package lucide
+
+import (
+	"errors"
+	"html/template"
+	"strconv"
+	"sync/atomic"
+)
+
+// Layer is a registered icon drawn as part of a Composition.
+type Layer struct {
+	// Name is the icon name or alias
+	Name string
+
+	// Scale resizes the layer's 24x24 box, e.g. 0.5 for half size
+	// (default: 1)
+	Scale float64
+
+	// X and Y move the top-left corner of the layer's box, in viewBox units
+	X, Y float64
+
+	// Color sets the stroke color of the layer (default: the color of the
+	// composed icon)
+	Color string
+
+	// Fill sets the fill paint of the layer (default: the fill of the
+	// composed icon)
+	Fill string
+}
+
+// Corner is a corner of the icon, for placing an IconBadge.
+type Corner int
+
+// Badge corners.
+const (
+	CornerTopRight Corner = iota
+	CornerTopLeft
+	CornerBottomRight
+	CornerBottomLeft
+)
+
+// IconBadge is a dot or small icon drawn in a corner of a Composition. The
+// layers below are cut away around it, like the "-check" and "-plus"
+// variants of Lucide icons.
+type IconBadge struct {
+	// Corner places the badge (default: CornerTopRight)
+	Corner Corner
+
+	// Name is the icon drawn as the badge. Empty draws a filled dot.
+	Name string
+
+	// Size is the badge diameter in viewBox units (default: 8 for a dot,
+	// 10 for an icon)
+	Size float64
+
+	// Color sets the dot fill or icon stroke color (default: the color of
+	// the composed icon)
+	Color string
+}
+
+// badgeGap is the space cut away around a badge, in viewBox units.
+const badgeGap = 2
+
+// maskIDCounter generates unique mask ids for badged compositions.
+var maskIDCounter atomic.Uint64
+
+// Composition is an icon made of several registered icons drawn into one
+// <svg>, optionally with a badge in a corner.
+//
+// Usage:
+//
+//	svg, err := lucide.Composition{
+//	    Layers: []lucide.Layer{
+//	        {Name: "file"},
+//	        {Name: "lock", Scale: 0.5, X: 6, Y: 10, Color: "red"},
+//	    },
+//	    Badge: &lucide.IconBadge{Name: "plus"},
+//	}.Render(lucide.Options{Size: 32})
+type Composition struct {
+	// Layers are drawn in order, later layers on top
+	Layers []Layer
+
+	// Badge is drawn in a corner above the layers, if set
+	Badge *IconBadge
+}
+
+// Render renders the composition with the options of the composed icon,
+// such as Size, Color and Class. With RTL, the whole composition is mirrored
+// if any layer is direction-sensitive. It reports an *ErrUnknownIcon for
+// unknown layer or badge icons and an *ErrInvalidOption for invalid options
+// or layer colors.
+func (c Composition) Render(opts ...Options) (template.HTML, error) {
+	if len(c.Layers) == 0 {
+		return template.HTML(""), errors.New("lucide: composition has no layers")
+	}
+
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+		if err := opt.Validate(); err != nil {
+			return template.HTML(""), err
+		}
+	}
+	strokeWidth := opt.strokeWidth()
+
+	var defs, b []byte
+	if c.Badge != nil {
+		mask := maskID(opt.Attrs["id"])
+		defs = append(defs, `<mask id="`...)
+		defs = appendEscaped(defs, mask)
+		defs = append(defs, `"><rect width="24" height="24" fill="white" stroke="none" />`...)
+		cx, cy, r := c.Badge.circle()
+		defs = appendCircle(defs, cx, cy, r+badgeGap, "black")
+		defs = append(defs, `</mask>`...)
+
+		b = append(b, `<g mask="url(#`...)
+		b = appendEscaped(b, mask)
+		b = append(b, `)">`...)
+	}
+
+	mirror := false
+	for _, layer := range c.Layers {
+		ic, err := lookupIcon(layer.Name)
+		if err != nil {
+			return template.HTML(""), err
+		}
+		mirror = mirror || ic.mirrorRTL
+		if layer.Color != "" && !isCSSColor(layer.Color) {
+			return template.HTML(""), &ErrInvalidOption{Option: "color", Value: layer.Color}
+		}
+		if layer.Fill != "" && !isCSSColor(layer.Fill) {
+			return template.HTML(""), &ErrInvalidOption{Option: "fill", Value: layer.Fill}
+		}
+		if layer.Scale < 0 {
+			return template.HTML(""), &ErrInvalidOption{Option: "scale", Value: layer.Scale}
+		}
+
+		b = appendLayer(b, ic.paths, layer, strokeWidth)
+	}
+
+	if c.Badge != nil {
+		b = append(b, `</g>`...)
+
+		var err error
+		if b, err = c.Badge.append(b, strokeWidth); err != nil {
+			return template.HTML(""), err
+		}
+	}
+
+	if opt.RTL && mirror {
+		opt.FlipX = !opt.FlipX
+	}
+	opt.RTL = false
+
+	return buildSVGDefs(string(defs), string(b), opt), nil
+}
+
+// WithBadge renders an icon with a badge in a corner, cutting the icon away
+// around the badge. It reports the same errors as Composition.Render.
+//
+// Usage:
+//
+//	lucide.WithBadge("bell", lucide.IconBadge{Color: "red"})
+//	lucide.WithBadge("user", lucide.IconBadge{Name: "plus", Corner: lucide.CornerBottomRight})
+func WithBadge(name string, badge IconBadge, opts ...Options) (template.HTML, error) {
+	return Composition{Layers: []Layer{{Name: name}}, Badge: &badge}.Render(opts...)
+}
+
+// circle returns the center and radius of the badge.
+func (bg *IconBadge) circle() (cx, cy, r float64) {
+	size := bg.Size
+	if size <= 0 {
+		size = 8
+		if bg.Name != "" {
+			size = 10
+		}
+	}
+	r = min(size, 24) / 2
+
+	cx, cy = 24-r, r
+	switch bg.Corner {
+	case CornerTopLeft:
+		cx = r
+	case CornerBottomRight:
+		cy = 24 - r
+	case CornerBottomLeft:
+		cx, cy = r, 24-r
+	}
+	return cx, cy, r
+}
+
+// append appends the badge markup to b.
+func (bg *IconBadge) append(b []byte, strokeWidth float64) ([]byte, error) {
+	if bg.Color != "" && !isCSSColor(bg.Color) {
+		return b, &ErrInvalidOption{Option: "color", Value: bg.Color}
+	}
+
+	cx, cy, r := bg.circle()
+	if bg.Name == "" {
+		color := bg.Color
+		if color == "" {
+			color = "currentColor"
+		}
+		return appendCircle(b, cx, cy, r, color), nil
+	}
+
+	ic, err := lookupIcon(bg.Name)
+	if err != nil {
+		return b, err
+	}
+	layer := Layer{Scale: 2 * r / 24, X: cx - r, Y: cy - r, Color: bg.Color}
+	return appendLayer(b, ic.paths, layer, strokeWidth), nil
+}
+
+// appendLayer appends the paths of a layer, wrapped in a group that
+// positions and colors it. The stroke width is divided by the scale, so
+// scaled layers keep the stroke width of the composed icon.
+func appendLayer(b []byte, paths string, layer Layer, strokeWidth float64) []byte {
+	scale := layer.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	if scale == 1 && layer.X == 0 && layer.Y == 0 && layer.Color == "" && layer.Fill == "" {
+		return append(b, paths...)
+	}
+
+	b = append(b, `<g`...)
+	if scale != 1 || layer.X != 0 || layer.Y != 0 {
+		b = append(b, ` transform="translate(`...)
+		b = appendNumber(b, layer.X)
+		b = append(b, ' ')
+		b = appendNumber(b, layer.Y)
+		b = append(b, `) scale(`...)
+		b = appendNumber(b, scale)
+		b = append(b, `)"`...)
+	}
+	if scale != 1 {
+		b = append(b, ` stroke-width="`...)
+		b = appendNumber(b, strokeWidth/scale)
+		b = append(b, '"')
+	}
+	if layer.Color != "" {
+		b = append(b, ` stroke="`...)
+		b = appendEscaped(b, layer.Color)
+		b = append(b, '"')
+	}
+	if layer.Fill != "" {
+		b = append(b, ` fill="`...)
+		b = appendEscaped(b, layer.Fill)
+		b = append(b, '"')
+	}
+	b = append(b, '>')
+	b = append(b, paths...)
+	return append(b, `</g>`...)
+}
+
+// appendCircle appends a filled circle without stroke.
+func appendCircle(b []byte, cx, cy, r float64, fill string) []byte {
+	b = append(b, `<circle cx="`...)
+	b = appendNumber(b, cx)
+	b = append(b, `" cy="`...)
+	b = appendNumber(b, cy)
+	b = append(b, `" r="`...)
+	b = appendNumber(b, r)
+	b = append(b, `" fill="`...)
+	b = appendEscaped(b, fill)
+	return append(b, `" stroke="none" />`...)
+}
+
+// maskID returns the id of the badge mask for an icon with the given id
+// attribute, or a generated unique id.
+func maskID(base string) string {
+	if base != "" {
+		return base + "-mask"
+	}
+	return "lucide-mask-" + strconv.FormatUint(maskIDCounter.Add(1), 10)
+}
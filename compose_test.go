@@ -0,0 +1,170 @@
+package lucide
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestComposition(t *testing.T) {
+	got, err := Composition{
+		Layers: []Layer{
+			{Name: "file"},
+			{Name: "lock", Scale: 0.5, X: 6, Y: 10, Color: "red"},
+		},
+	}.Render(Options{Size: 32})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`width="32"`,
+		iconFile.paths,
+		`<g transform="translate(6 10) scale(0.5)" stroke-width="4" stroke="red">` + iconLock.paths + `</g>`,
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Render() = %v, want to contain %v", got, want)
+		}
+	}
+	if strings.Count(string(got), "<svg") != 1 {
+		t.Errorf("Render() = %v, want a single <svg>", got)
+	}
+}
+
+func TestCompositionAnimated(t *testing.T) {
+	got, err := WithBadge("bell", IconBadge{Color: "red"}, Options{Animation: "pulse", Attrs: map[string]string{"id": "bell"}})
+	if err != nil {
+		t.Fatalf("WithBadge() error = %v", err)
+	}
+
+	// The mask is defined once, outside the paths repeated by the still copy.
+	if n := strings.Count(string(got), `<mask id="bell-mask">`); n != 1 {
+		t.Errorf("WithBadge() = %v, has %d masks, want 1", got, n)
+	}
+	if !strings.Contains(string(got), `</mask><g class="bell-motion">`) {
+		t.Errorf("WithBadge() = %v, want the mask before the animated group", got)
+	}
+}
+
+func TestCompositionRTL(t *testing.T) {
+	tests := []struct {
+		name   string
+		layers []Layer
+		want   bool
+	}{
+		{"direction-sensitive layer", []Layer{{Name: "file"}, {Name: "arrow-left", Scale: 0.5}}, true},
+		{"no direction-sensitive layer", []Layer{{Name: "file"}, {Name: "lock", Scale: 0.5}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Composition{Layers: tt.layers}.Render(Options{RTL: true})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if mirrored := strings.Contains(string(got), `<g transform="matrix(-1 0 0 1 24 0)">`); mirrored != tt.want {
+				t.Errorf("Render() = %v, mirrored = %v, want %v", got, mirrored, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompositionErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		composition Composition
+		opts        Options
+		wantOption  string
+	}{
+		{name: "no layers"},
+		{
+			name:        "unknown layer",
+			composition: Composition{Layers: []Layer{{Name: "doesnt-exist"}}},
+		},
+		{
+			name:        "invalid layer color",
+			composition: Composition{Layers: []Layer{{Name: "file", Color: `red" onload="x`}}},
+			wantOption:  "color",
+		},
+		{
+			name:        "negative scale",
+			composition: Composition{Layers: []Layer{{Name: "file", Scale: -1}}},
+			wantOption:  "scale",
+		},
+		{
+			name:        "invalid badge color",
+			composition: Composition{Layers: []Layer{{Name: "file"}}, Badge: &IconBadge{Color: "nope"}},
+			wantOption:  "color",
+		},
+		{
+			name:        "invalid options",
+			composition: Composition{Layers: []Layer{{Name: "file"}}},
+			opts:        Options{Width: "1parsec"},
+			wantOption:  "width",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.composition.Render(tt.opts)
+			if err == nil || got != "" {
+				t.Fatalf("Render() = %v, %v, want error", got, err)
+			}
+
+			var invalid *ErrInvalidOption
+			if tt.wantOption != "" && (!errors.As(err, &invalid) || invalid.Option != tt.wantOption) {
+				t.Errorf("Render() error = %v, want *ErrInvalidOption for %q", err, tt.wantOption)
+			}
+		})
+	}
+}
+
+func TestWithBadge(t *testing.T) {
+	tests := []struct {
+		name  string
+		badge IconBadge
+		want  []string
+	}{
+		{
+			name:  "dot",
+			badge: IconBadge{Color: "red"},
+			want: []string{
+				`<circle cx="20" cy="4" r="6" fill="black" stroke="none" />`,
+				`<circle cx="20" cy="4" r="4" fill="red" stroke="none" />`,
+			},
+		},
+		{
+			name:  "icon bottom left",
+			badge: IconBadge{Name: "plus", Corner: CornerBottomLeft, Size: 12},
+			want: []string{
+				`<circle cx="6" cy="18" r="8" fill="black" stroke="none" />`,
+				`<g transform="translate(0 12) scale(0.5)" stroke-width="4">` + iconPlus.paths + `</g>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WithBadge("bell", tt.badge, Options{Attrs: map[string]string{"id": "b"}})
+			if err != nil {
+				t.Fatalf("WithBadge() error = %v", err)
+			}
+
+			want := append([]string{
+				`<mask id="b-mask"><rect width="24" height="24" fill="white" stroke="none" />`,
+				`<g mask="url(#b-mask)">` + iconBell.paths + `</g>`,
+			}, tt.want...)
+			for _, w := range want {
+				if !strings.Contains(string(got), w) {
+					t.Errorf("WithBadge() = %v, want to contain %v", got, w)
+				}
+			}
+		})
+	}
+
+	a, _ := WithBadge("bell", IconBadge{})
+	b, _ := WithBadge("bell", IconBadge{})
+	if a == b {
+		t.Errorf("WithBadge() rendered the same mask id twice: %v", a)
+	}
+}
@@ -8,16 +8,23 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
 )
 
 type Icon struct {
-	Name       string
-	PascalName string
-	Paths      string
-	Aliases    []Alias
+	Name              string
+	PascalName        string
+	Paths             string
+	Aliases           []Alias
+	Tags              []string
+	Categories        []string
+	Contributors      []string
+	DeprecationReason string
+	Deprecated        bool
+	MirrorRTL         bool
 }
 
 type Alias struct {
@@ -35,6 +42,11 @@ type iconMetadata struct {
 		DeprecationReason string `json:"deprecationReason,omitempty"`
 		Deprecated        bool   `json:"deprecated,omitempty"`
 	} `json:"aliases,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+	Categories        []string `json:"categories,omitempty"`
+	Contributors      []string `json:"contributors,omitempty"`
+	DeprecationReason string   `json:"deprecationReason,omitempty"`
+	Deprecated        bool     `json:"deprecated,omitempty"`
 }
 
 type Result struct {
@@ -57,7 +69,9 @@ func New(iconsDir, outputFile string) *Generator {
 }
 
 // Generate reads SVG files from the icons directory and generates the output Go file.
-// Returns statistics about the generation process.
+// Returns statistics about the generation process. It fails if no icon has tags,
+// which means the .json metadata files of the Lucide release are missing and
+// the generated icons would have no tags for search and suggestions.
 func (g *Generator) Generate() (*Result, error) {
 	files, err := filepath.Glob(filepath.Join(g.IconsDir, "*.svg"))
 	if err != nil {
@@ -77,6 +91,10 @@ func (g *Generator) Generate() (*Result, error) {
 		icons = append(icons, icon)
 	}
 
+	if !slices.ContainsFunc(icons, func(icon Icon) bool { return len(icon.Tags) > 0 }) {
+		return nil, fmt.Errorf("no icon tags found in %s: the .json metadata files are missing", g.IconsDir)
+	}
+
 	sort.Slice(icons, func(i, j int) bool {
 		return icons[i].Name < icons[j].Name
 	})
@@ -104,10 +122,17 @@ func processIcon(svgPath string, iconsDir string) (Icon, error) {
 		Name:       name,
 		PascalName: toPascalCase(name),
 		Paths:      paths,
+		MirrorRTL:  mirrorsInRTL(name),
 	}
 
 	metadataPath := filepath.Join(iconsDir, name+".json")
 	if metadata, err := readMetadata(metadataPath); err == nil {
+		icon.Tags = metadata.Tags
+		icon.Categories = metadata.Categories
+		icon.Contributors = metadata.Contributors
+		icon.DeprecationReason = metadata.DeprecationReason
+		icon.Deprecated = metadata.Deprecated
+
 		for _, alias := range metadata.Aliases {
 
 			aliasPascalName := toPascalCase(alias.Name)
@@ -129,6 +154,53 @@ func processIcon(svgPath string, iconsDir string) (Icon, error) {
 	return icon, nil
 }
 
+// rtlWords are name words of icons whose meaning follows the reading
+// direction, such as arrows pointing back or forward.
+var rtlWords = map[string]bool{
+	"left":   true,
+	"right":  true,
+	"undo":   true,
+	"redo":   true,
+	"reply":  true,
+	"indent": true,
+}
+
+// rtlIcons are direction-sensitive icons without an rtlWords word.
+var rtlIcons = map[string]bool{
+	"log-in":          true,
+	"log-out":         true,
+	"send":            true,
+	"send-horizontal": true,
+}
+
+// rtlExcludedWords are name words of icons that say left or right but
+// depict a physical thing or a fixed convention, which is not mirrored.
+var rtlExcludedWords = map[string]bool{
+	"mouse":    true,
+	"toggle":   true,
+	"decimals": true,
+	"pilcrow":  true,
+}
+
+// mirrorsInRTL reports whether the icon is direction-sensitive and should be
+// mirrored horizontally in right-to-left layouts.
+func mirrorsInRTL(name string) bool {
+	if rtlIcons[name] {
+		return true
+	}
+
+	mirror := false
+	for _, word := range strings.Split(name, "-") {
+		if rtlExcludedWords[word] {
+			return false
+		}
+		if rtlWords[word] {
+			mirror = true
+		}
+	}
+	return mirror
+}
+
 func readMetadata(path string) (*iconMetadata, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
@@ -177,9 +249,28 @@ func toPascalCase(s string) string {
 	return strings.Join(result, "")
 }
 
+// deprecationNotice describes why an alias is deprecated, for use in the
+// "Deprecated:" paragraph of its doc comment.
+func deprecationNotice(a Alias) string {
+	switch a.DeprecationReason {
+	case "alias.name":
+		return fmt.Sprintf("%q was renamed to %q.", a.Name, a.TargetName)
+	case "alias.typo":
+		return fmt.Sprintf("%q is a misspelling of %q.", a.Name, a.TargetName)
+	case "alias.duplicate":
+		return fmt.Sprintf("%q is a duplicate of %q.", a.Name, a.TargetName)
+	case "":
+		return fmt.Sprintf("%q is deprecated.", a.Name)
+	default:
+		return fmt.Sprintf("%q is deprecated (%s).", a.Name, a.DeprecationReason)
+	}
+}
+
 func generateFile(outputPath string, icons []Icon) error {
 	var buf bytes.Buffer
-	tmpl := template.Must(template.New("icons").Parse(iconTemplate))
+	tmpl := template.Must(template.New("icons").Funcs(template.FuncMap{
+		"deprecation": deprecationNotice,
+	}).Parse(iconTemplate))
 	if err := tmpl.Execute(&buf, icons); err != nil {
 		return err
 	}
@@ -196,19 +287,72 @@ const iconTemplate = `// Code generated by cmd/generate. DO NOT EDIT.
 
 package lucide
 
-import "html/template"
+import (
+	"html/template"
+	"io"
+)
 
 func init() {
 {{- range . }}
-	registerIcon("{{ .Name }}", {{ .PascalName }})
+	registerIcon(icon{{ .PascalName }})
+{{- end }}
+}
+
+// Name is the name of an icon. Use the Name constants for compile-time
+// checked icon references:
+//
+//	lucide.IconByName(lucide.NameCircleX)
+type Name string
+
+// Icon names, including aliases.
+const (
+{{- range . }}
+	// Name{{ .PascalName }} is the name of the "{{ .Name }}" icon.
+	Name{{ .PascalName }} Name = "{{ .Name }}"
 {{- range .Aliases }}
-	registerIcon("{{ .Name }}", {{ .PascalName }})
+	// Name{{ .PascalName }} is an alias for Name{{ .TargetPascalName }}.
+{{- if .Deprecated }}
+	//
+	// Deprecated: {{ deprecation . }}
+	// Use Name{{ .TargetPascalName }} instead.
 {{- end }}
+	Name{{ .PascalName }} Name = "{{ .Name }}"
 {{- end }}
-}
+{{- end }}
+)
 
 {{- range . }}
 
+var icon{{ .PascalName }} = &icon{
+	name:  "{{ .Name }}",
+	paths: ` + "`" + `{{ .Paths }}` + "`" + `,
+{{- with .Aliases }}
+	aliases: []iconAlias{
+{{- range . }}
+		{name: "{{ .Name }}"{{ if .Deprecated }}, deprecated: true{{ end }}{{ with .DeprecationReason }}, deprecationReason: {{ printf "%q" . }}{{ end }}},
+{{- end }}
+	},
+{{- end }}
+{{- with .Tags }}
+	tags: {{ template "strings" . }},
+{{- end }}
+{{- with .Categories }}
+	categories: {{ template "strings" . }},
+{{- end }}
+{{- with .Contributors }}
+	contributors: {{ template "strings" . }},
+{{- end }}
+{{- if .Deprecated }}
+	deprecated: true,
+{{- end }}
+{{- with .DeprecationReason }}
+	deprecationReason: {{ printf "%q" . }},
+{{- end }}
+{{- if .MirrorRTL }}
+	mirrorRTL: true,
+{{- end }}
+}
+
 // {{ .PascalName }} renders the "{{ .Name }}" icon.
 //
 // Usage in templates:
@@ -217,30 +361,25 @@ func init() {
 // Direct usage in Go:
 //   lucide.{{ .PascalName }}()
 //   lucide.{{ .PascalName }}(lucide.Options{Size: 32, Class: "my-icon"})
+{{- if .Deprecated }}
+//
+// Deprecated: The "{{ .Name }}" icon is deprecated upstream{{ with .DeprecationReason }} ({{ . }}){{ end }}.
+{{- end }}
 func {{ .PascalName }}(opts ...Options) template.HTML {
-	opt := Options{Size: 24, StrokeWidth: 2}
-	if len(opts) > 0 {
-		opt = opts[0]
-		if opt.Size == 0 {
-			opt.Size = 24
-		}
-		if opt.StrokeWidth == 0 {
-			opt.StrokeWidth = 2
-		}
-	}
-	return buildSVG(` + "`" + `{{ .Paths }}` + "`" + `, opt)
+	return icon{{ .PascalName }}.render(opts)
+}
+
+// Write{{ .PascalName }} writes the "{{ .Name }}" icon to w.
+//
+// Direct usage in Go:
+//   lucide.Write{{ .PascalName }}(w)
+//   lucide.Write{{ .PascalName }}(w, lucide.Options{Size: 32, Class: "my-icon"})
+func Write{{ .PascalName }}(w io.Writer, opts ...Options) error {
+	return icon{{ .PascalName }}.writeTo(w, opts)
 }
 {{- range .Aliases }}
 
 // {{ .PascalName }} is an alias for {{ .TargetPascalName }}.
-{{- if .Deprecated }}
-//
-// Deprecated: This icon name is deprecated and will be removed in a future version.
-{{- if .DeprecationReason }}
-// Reason: {{ .DeprecationReason }}
-{{- end }}
-// Please use {{ .TargetPascalName }} instead.
-{{- end }}
 //
 // Usage in templates:
 //   {{ "{{" }} lucide "{{ .Name }}" {{ "}}" }}
@@ -248,9 +387,26 @@ func {{ .PascalName }}(opts ...Options) template.HTML {
 // Direct usage in Go:
 //   lucide.{{ .PascalName }}()
 //   lucide.{{ .PascalName }}(lucide.Options{Size: 32, Class: "my-icon"})
+{{- if .Deprecated }}
+//
+// Deprecated: {{ deprecation . }}
+// Use {{ .TargetPascalName }} instead.
+{{- end }}
 func {{ .PascalName }}(opts ...Options) template.HTML {
 	return {{ .TargetPascalName }}(opts...)
 }
+
+// Write{{ .PascalName }} is an alias for Write{{ .TargetPascalName }}.
+{{- if .Deprecated }}
+//
+// Deprecated: {{ deprecation . }}
+// Use Write{{ .TargetPascalName }} instead.
+{{- end }}
+func Write{{ .PascalName }}(w io.Writer, opts ...Options) error {
+	return Write{{ .TargetPascalName }}(w, opts...)
+}
 {{- end }}
 {{- end }}
+
+{{- define "strings" }}[]string{ {{- range $i, $s := . }}{{ if $i }}, {{ end }}{{ printf "%q" $s }}{{ end -}} }{{ end }}
 `
@@ -3,6 +3,7 @@ package generator
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -324,9 +325,209 @@ func TestReadMetadata(t *testing.T) {
 	}
 }
 
+func TestProcessIconWithMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testSVG := `<svg xmlns="http://www.w3.org/2000/svg"><circle cx="12" cy="12" r="10" /></svg>`
+	if err := os.WriteFile(filepath.Join(tmpDir, "test-icon.svg"), []byte(testSVG), 0o644); err != nil {
+		t.Fatalf("failed to create test SVG: %v", err)
+	}
+
+	testMetadata := `{
+  "contributors": ["alice", "bob"],
+  "tags": ["round", "shape"],
+  "categories": ["shapes"],
+  "deprecated": true,
+  "deprecationReason": "icon.brand"
+}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "test-icon.json"), []byte(testMetadata), 0o644); err != nil {
+		t.Fatalf("failed to create test metadata: %v", err)
+	}
+
+	icon, err := processIcon(filepath.Join(tmpDir, "test-icon.svg"), tmpDir)
+	if err != nil {
+		t.Fatalf("processIcon() failed: %v", err)
+	}
+
+	if strings.Join(icon.Tags, ",") != "round,shape" {
+		t.Errorf("processIcon().Tags = %v, want [round shape]", icon.Tags)
+	}
+	if strings.Join(icon.Categories, ",") != "shapes" {
+		t.Errorf("processIcon().Categories = %v, want [shapes]", icon.Categories)
+	}
+	if strings.Join(icon.Contributors, ",") != "alice,bob" {
+		t.Errorf("processIcon().Contributors = %v, want [alice bob]", icon.Contributors)
+	}
+	if !icon.Deprecated || icon.DeprecationReason != "icon.brand" {
+		t.Errorf("processIcon() deprecation = %v %q, want true %q", icon.Deprecated, icon.DeprecationReason, "icon.brand")
+	}
+}
+
 func TestReadMetadataFileNotFound(t *testing.T) {
 	_, err := readMetadata("/nonexistent/file.json")
 	if err == nil {
 		t.Error("readMetadata() should return error for nonexistent file")
 	}
 }
+
+func TestGenerateFile(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "icons.go")
+
+	icons := []Icon{
+		{
+			Name:       "test-icon",
+			PascalName: "TestIcon",
+			Paths:      `<circle cx="12" cy="12" r="10" />`,
+			Tags:       []string{"round", "shape"},
+			Categories: []string{"shapes"},
+			Aliases: []Alias{
+				{
+					Name:             "old-name",
+					PascalName:       "OldName",
+					TargetName:       "test-icon",
+					TargetPascalName: "TestIcon",
+				},
+			},
+		},
+	}
+
+	if err := generateFile(outputPath, icons); err != nil {
+		t.Fatalf("generateFile() failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read generated file: %v", err)
+	}
+
+	for _, want := range []string{
+		`registerIcon(iconTestIcon)`,
+		`{name: "old-name"},`,
+		`tags:       []string{"round", "shape"},`,
+		`categories: []string{"shapes"},`,
+		"paths: `<circle cx=\"12\" cy=\"12\" r=\"10\" />`",
+		`func TestIcon(opts ...Options) template.HTML {`,
+		`return iconTestIcon.render(opts)`,
+		`func OldName(opts ...Options) template.HTML {`,
+		`NameTestIcon Name = "test-icon"`,
+		`NameOldName Name = "old-name"`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated file missing %q", want)
+		}
+	}
+}
+
+func TestGenerateFileDeprecatedAlias(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "icons.go")
+
+	icons := []Icon{
+		{
+			Name:       "test-icon",
+			PascalName: "TestIcon",
+			Paths:      `<circle cx="12" cy="12" r="10" />`,
+			Aliases: []Alias{
+				{
+					Name:              "old-name",
+					PascalName:        "OldName",
+					TargetName:        "test-icon",
+					TargetPascalName:  "TestIcon",
+					DeprecationReason: "alias.typo",
+					Deprecated:        true,
+				},
+			},
+		},
+	}
+
+	if err := generateFile(outputPath, icons); err != nil {
+		t.Fatalf("generateFile() failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read generated file: %v", err)
+	}
+
+	for _, want := range []string{
+		"// Deprecated: \"old-name\" is a misspelling of \"test-icon\".\n// Use TestIcon instead.\nfunc OldName(",
+		"// Deprecated: \"old-name\" is a misspelling of \"test-icon\".\n// Use WriteTestIcon instead.\nfunc WriteOldName(",
+		"\t// Deprecated: \"old-name\" is a misspelling of \"test-icon\".\n\t// Use NameTestIcon instead.\n\tNameOldName Name",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated file missing %q", want)
+		}
+	}
+}
+
+func TestDeprecationNotice(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   string
+	}{
+		{"alias.name", `"old" was renamed to "new".`},
+		{"alias.typo", `"old" is a misspelling of "new".`},
+		{"alias.duplicate", `"old" is a duplicate of "new".`},
+		{"", `"old" is deprecated.`},
+		{"something.else", `"old" is deprecated (something.else).`},
+	}
+
+	for _, tt := range tests {
+		alias := Alias{Name: "old", TargetName: "new", DeprecationReason: tt.reason}
+		if got := deprecationNotice(alias); got != tt.want {
+			t.Errorf("deprecationNotice(%q) = %q, want %q", tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestMirrorsInRTL(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"arrow-left", true},
+		{"chevrons-right", true},
+		{"undo-2", true},
+		{"message-square-reply", true},
+		{"log-out", true},
+		{"arrow-up", false},
+		{"send-to-back", false},
+		{"mouse-left", false},
+		{"toggle-right", false},
+		{"circle-x", false},
+	}
+
+	for _, tt := range tests {
+		if got := mirrorsInRTL(tt.name); got != tt.want {
+			t.Errorf("mirrorsInRTL(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateRequiresMetadata(t *testing.T) {
+	iconsDir := t.TempDir()
+	outputFile := filepath.Join(t.TempDir(), "icons.go")
+
+	testSVG := `<svg xmlns="http://www.w3.org/2000/svg"><circle cx="12" cy="12" r="10" /></svg>`
+	if err := os.WriteFile(filepath.Join(iconsDir, "test-icon.svg"), []byte(testSVG), 0o644); err != nil {
+		t.Fatalf("failed to create test SVG: %v", err)
+	}
+
+	if _, err := New(iconsDir, outputFile).Generate(); err == nil {
+		t.Fatal("Generate() without metadata should return error")
+	}
+	if _, err := os.Stat(outputFile); !os.IsNotExist(err) {
+		t.Errorf("Generate() without metadata wrote %s", outputFile)
+	}
+
+	if err := os.WriteFile(filepath.Join(iconsDir, "test-icon.json"), []byte(`{"tags": ["round"]}`), 0o644); err != nil {
+		t.Fatalf("failed to create test metadata: %v", err)
+	}
+
+	result, err := New(iconsDir, outputFile).Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if result.IconsGenerated != 1 {
+		t.Errorf("Generate().IconsGenerated = %d, want 1", result.IconsGenerated)
+	}
+}
@@ -19,8 +19,75 @@ package lucide
 import (
 	"fmt"
 	"html/template"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
+// ErrUnknownIcon is returned when an icon name is not present in the registry.
+//
+// Use errors.As to inspect it:
+//
+//	var unknown *lucide.ErrUnknownIcon
+//	if errors.As(err, &unknown) {
+//	    log.Printf("missing icon %q, did you mean %v", unknown.Name, unknown.Suggestions)
+//	}
+type ErrUnknownIcon struct {
+	// Name is the icon name that was requested
+	Name string
+
+	// Suggestions are the closest registered names, see Suggest
+	Suggestions []string
+}
+
+func (e *ErrUnknownIcon) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("lucide: unknown icon %q", e.Name)
+	}
+
+	quoted := make([]string, len(e.Suggestions))
+	for i, s := range e.Suggestions {
+		quoted[i] = strconv.Quote(s)
+	}
+	return fmt.Sprintf("lucide: unknown icon %q (did you mean %s?)", e.Name, strings.Join(quoted, ", "))
+}
+
+// ErrInvalidOption is returned when an option value cannot be rendered,
+// for example a width with an unsupported CSS unit.
+type ErrInvalidOption struct {
+	// Option is the name of the option, as used in template dicts
+	Option string
+
+	// Value is the rejected value
+	Value any
+}
+
+func (e *ErrInvalidOption) Error() string {
+	return fmt.Sprintf("lucide: invalid value %v for option %q", e.Value, e.Option)
+}
+
+// ErrUnknownOption is returned when an option name passed to the icon
+// function is not recognized, for example a misspelled dict key.
+type ErrUnknownOption struct {
+	// Option is the unrecognized option name
+	Option string
+}
+
+func (e *ErrUnknownOption) Error() string {
+	return fmt.Sprintf("lucide: unknown option %q", e.Option)
+}
+
+// ErrUnknownPreset is returned when the icon function is called with a
+// preset name that is not in Config.Presets.
+type ErrUnknownPreset struct {
+	// Name is the preset name that was requested
+	Name string
+}
+
+func (e *ErrUnknownPreset) Error() string {
+	return fmt.Sprintf("lucide: unknown preset %q", e.Name)
+}
+
 // Config configures the template function map returned by FuncMap.
 type Config struct {
 	// FuncName is the icon function name (default: "lucide")
@@ -32,6 +99,60 @@ type Config struct {
 
 	// DictName is the dict function name (default: "dict")
 	DictName string
+
+	// Strict makes the icon function return an error for unknown icon names,
+	// unknown option names and invalid option values, which aborts
+	// html/template execution (default: false, meaning unknown icons render
+	// as an empty string and invalid options are ignored)
+	Strict bool
+
+	// Cache caches rendered icons for the icon function. When nil, the
+	// package level cache set with SetCache is used, if any.
+	Cache *Cache
+
+	// RTL renders all icons of the icon function for right-to-left text,
+	// see Options.RTL
+	RTL bool
+
+	// Defaults are the options of the icon function, merged under the
+	// options of each call. Classes are appended rather than replaced and
+	// attributes are merged. Fields left zero keep the built-in defaults.
+	Defaults Options
+
+	// Presets are named options, such as sizes of a design system, selected
+	// by passing the name as the first option of the icon function:
+	//
+	//	{{ lucide "menu" "lg" }}
+	//	{{ lucide "menu" "lg" (dict "class" "nav") }}
+	//	{{ lucide "menu" "lg" "class" "nav" }}
+	//
+	// Presets are merged over Defaults, and options passed after the preset
+	// name over the preset. Dict keys and inline pairs override the preset
+	// even with zero values, such as "filled" false. Unknown preset names
+	// are handled like unknown icons: an *ErrUnknownPreset in Strict mode,
+	// the Fallback otherwise.
+	Presets map[string]Options
+
+	// Fallback is rendered in place of unknown icon names, with the options
+	// of the call. It is either an icon name, such as "circle-help", or SVG
+	// markup as accepted by Register. Fallback is not used in Strict mode.
+	Fallback string
+
+	// OnMissing is called with the name of each unknown icon requested
+	// through the icon function, e.g. to log or count it. It must be safe
+	// for concurrent use.
+	OnMissing func(name string)
+
+	// OnDeprecated is called with the name of each deprecated icon or alias
+	// rendered through the icon function and its replacement, which is
+	// empty if there is none. It must be safe for concurrent use.
+	OnDeprecated func(name, replacement string)
+
+	// OnInvalidOption is called with the icon name and an *ErrUnknownOption,
+	// *ErrInvalidOption or *ErrUnknownPreset for each unknown option name,
+	// invalid option value or unknown preset passed to the icon function,
+	// also in Strict mode. It must be safe for concurrent use.
+	OnInvalidOption func(name string, err error)
 }
 
 // Options configures individual icon rendering.
@@ -39,57 +160,168 @@ type Options struct {
 	// Size sets both width and height in pixels (default: 24)
 	Size int
 
-	// Color sets the color of the stroke. (default: currentColor)
+	// Width overrides the width with a number or CSS length such as "1em",
+	// "1.25rem" or "100%"
+	Width string
+
+	// Height overrides the height with a number or CSS length
+	Height string
+
+	// OmitSize leaves out the width and height attributes so CSS controls
+	// the size of the icon
+	OmitSize bool
+
+	// Color sets the color of the stroke. Must be a CSS color: a named color,
+	// hex, rgb()/hsl() and friends, var(--name) or currentColor.
+	// (default: currentColor)
 	Color string
 
-	// StrokeWidth sets the stroke width (default: 2)
-	StrokeWidth int
+	// StrokeWidth sets the stroke width, fractional values such as 1.5 are
+	// allowed (default: 2)
+	StrokeWidth float64
+
+	// AbsoluteStrokeWidth keeps the stroke the same visual thickness at any
+	// Size by scaling StrokeWidth against the 24 unit viewBox
+	AbsoluteStrokeWidth bool
+
+	// Fill sets the fill paint of the icon shapes, using the same grammar as
+	// Color (default: none)
+	Fill string
+
+	// Filled fills the closed shapes with the stroke color, for solid
+	// variants such as an active heart or star. Fill takes precedence.
+	Filled bool
+
+	// StrokeLinecap sets the line cap: butt, round or square (default: round)
+	StrokeLinecap string
+
+	// StrokeLinejoin sets the line join: arcs, bevel, miter, miter-clip or
+	// round (default: round)
+	StrokeLinejoin string
 
 	// Class sets CSS classes to add to the SVG element
 	Class string
-}
 
-// iconRegistry maps icon names to their rendering functions.
-// This will be populated by the generated icons.go file.
-var iconRegistry = make(map[string]func(opts ...Options) template.HTML)
+	// Attrs sets additional attributes on the SVG element, such as id, style,
+	// data-* or aria-*. Values are HTML-escaped and attributes are written in
+	// sorted order. Attributes controlled by other fields (width, height,
+	// stroke, class, ...), event handlers (onload, onclick, ...) and invalid
+	// attribute names are ignored.
+	Attrs map[string]string
+
+	// Label is the accessible name of the icon. When set, the SVG gets
+	// role="img", aria-label and a <title> child referenced by
+	// aria-labelledby. Icons without a label are treated as decorative and
+	// get aria-hidden="true" unless Attrs sets role or an aria-* name.
+	Label string
+
+	// Rotate rotates the icon clockwise by the given degrees around its
+	// center, e.g. 90 or 180 for expand and collapse states of a chevron
+	Rotate float64
+
+	// FlipX mirrors the icon horizontally
+	FlipX bool
+
+	// FlipY mirrors the icon vertically
+	FlipY bool
+
+	// RTL renders the icon for right-to-left text: direction-sensitive
+	// icons, such as arrows, chevrons and undo, are mirrored horizontally
+	// and others are left as they are
+	RTL bool
+
+	// Animation animates the icon without external CSS: spin rotates it,
+	// e.g. for loader-circle, pulse fades it in and out and draw strokes
+	// its shapes in once. A still icon is shown instead to users who prefer
+	// reduced motion.
+	Animation string
+
+	// AnimationDuration is the duration of one animation cycle in seconds
+	// (default: 1 for spin, 2 for pulse and 1.5 for draw)
+	AnimationDuration float64
+
+	// Nonce is the Content-Security-Policy nonce of the <style> element
+	// emitted for animated icons
+	Nonce string
+}
 
 // Icon renders an icon by name with optional configuration.
-// This is the main template function.
+// This is the main template function. The name is a string or a Name.
+//
+// Options are maps of option names to values, such as the result of dict,
+// or Options values, optionally followed by inline key/value pairs. Values
+// are coerced where unambiguous, so a size may be an int64 from a database,
+// a float64 from JSON or a "32" from a query parameter. Unknown names and
+// invalid values are ignored; use FuncMap with Config.Strict or
+// Config.OnInvalidOption to report them.
 //
 // Usage in templates:
 //
 //	{{ lucide "circle-x" }}
 //	{{ lucide "play" (dict "size" 32) }}
 //	{{ lucide "menu" (dict "size" 24 "color" "red" "strokeWidth" 2 "class" "my-icon") }}
-func Icon(name string, options ...map[string]any) template.HTML {
-	opts := Options{
-		Size:        24,
-		Color:       "currentColor",
-		StrokeWidth: 2,
-		Class:       "",
+//	{{ lucide "menu" (dict "attrs" (dict "id" "menu-icon" "data-toggle" "nav")) }}
+//	{{ lucide "trash" (dict "label" "Delete item") }}
+//	{{ lucide "star" .IconOptions }}
+//	{{ lucide "play" "size" 32 "class" "my-icon" }}
+//	{{ lucide .IconName }}
+func Icon(name any, options ...any) template.HTML {
+	ic, ok := findIcon(iconName(name))
+	if !ok {
+		return template.HTML("")
 	}
 
-	if len(options) > 0 {
-		if size, ok := options[0]["size"].(int); ok {
-			opts.Size = size
-		}
-		if color, ok := options[0]["color"].(string); ok {
-			opts.Color = color
-		}
-		if strokeWidth, ok := options[0]["strokeWidth"].(int); ok {
-			opts.StrokeWidth = strokeWidth
-		}
-		if class, ok := options[0]["class"].(string); ok {
-			opts.Class = class
-		}
-	}
+	opts, _ := parseOptions(options...)
+	return ic.render([]Options{opts})
+}
 
-	iconFn, ok := iconRegistry[name]
+// IconByName renders an icon by its typed name. Unknown names, which can
+// only come from converted strings, render as an empty string.
+//
+// Usage:
+//
+//	lucide.IconByName(lucide.NameCircleX)
+//	lucide.IconByName(lucide.NamePlay, lucide.Options{Size: 32})
+func IconByName(name Name, opts ...Options) template.HTML {
+	ic, ok := findIcon(string(name))
 	if !ok {
 		return template.HTML("")
 	}
+	return ic.render(opts)
+}
 
-	return iconFn(opts)
+// iconName returns the name passed to Icon or the icon function, a string
+// or Name. Other values are formatted with fmt.Sprint, so they are
+// reported as unknown icons.
+func iconName(name any) string {
+	if s, ok := toString(name); ok {
+		return s
+	}
+	return fmt.Sprint(name)
+}
+
+// Render renders an icon by name and reports an *ErrUnknownIcon if the
+// name is not registered, or an *ErrInvalidOption if opts fails Validate.
+//
+// Usage:
+//
+//	svg, err := lucide.Render("circle-x", lucide.Options{Size: 32})
+//	if err != nil {
+//	    return err
+//	}
+func Render(name string, opts ...Options) (template.HTML, error) {
+	ic, err := lookupIcon(name)
+	if err != nil {
+		return template.HTML(""), err
+	}
+
+	for _, o := range opts {
+		if err := o.Validate(); err != nil {
+			return template.HTML(""), err
+		}
+	}
+
+	return ic.render(opts), nil
 }
 
 // FuncMap returns a template.FuncMap with icon functions registered.
@@ -110,32 +342,147 @@ func Icon(name string, options ...map[string]any) template.HTML {
 //	tmpl.Funcs(lucide.FuncMap(&lucide.Config{
 //	    SkipDict: true,
 //	}))
+//
+//	// Fail template execution on unknown icon names
+//	tmpl.Funcs(lucide.FuncMap(&lucide.Config{
+//	    Strict: true,
+//	}))
 func FuncMap(cfg ...*Config) template.FuncMap {
-	funcName := "lucide"
-	skipDict := false
-	dictName := "dict"
-
+	var c Config
 	if len(cfg) > 0 && cfg[0] != nil {
-		if cfg[0].FuncName != "" {
-			funcName = cfg[0].FuncName
-		}
-		skipDict = cfg[0].SkipDict
-		if cfg[0].DictName != "" {
-			dictName = cfg[0].DictName
-		}
+		c = *cfg[0]
+	}
+	if c.FuncName == "" {
+		c.FuncName = "lucide"
+	}
+	if c.DictName == "" {
+		c.DictName = "dict"
 	}
 
 	fm := template.FuncMap{
-		funcName: Icon,
+		c.FuncName: c.iconFunc(),
 	}
 
-	if !skipDict {
-		fm[dictName] = Dict
+	if !c.SkipDict {
+		fm[c.DictName] = Dict
 	}
 
 	return fm
 }
 
+// iconFunc returns the template icon function for the configuration.
+// Errors are only returned in strict mode; a non-nil error stops
+// html/template execution.
+func (c Config) iconFunc() func(name any, options ...any) (template.HTML, error) {
+	defaults := defaultOptions.merge(c.Defaults)
+	if c.RTL {
+		defaults.RTL = true
+	}
+
+	// Markup fallbacks are parsed once, names are looked up on each miss
+	// since the icon may be registered later.
+	var fallback *icon
+	if strings.HasPrefix(strings.TrimSpace(c.Fallback), "<") {
+		fallback = &icon{name: "fallback", paths: unwrapSVG(c.Fallback)}
+	}
+
+	presets := make(map[string]Options, len(c.Presets))
+	for name, preset := range c.Presets {
+		presets[name] = defaults.merge(preset)
+	}
+
+	return func(nameArg any, options ...any) (template.HTML, error) {
+		name := iconName(nameArg)
+		base, unknownPreset := defaults, false
+		if preset, ok := presetArg(presets, options); ok {
+			options = options[1:]
+			if base, ok = presets[preset]; !ok {
+				base, unknownPreset = defaults, true
+				err := &ErrUnknownPreset{Name: preset}
+				if c.OnInvalidOption != nil {
+					c.OnInvalidOption(name, err)
+				}
+				if c.Strict {
+					return template.HTML(""), err
+				}
+			}
+		}
+
+		opts, errs := mergeOptions(base, options)
+		if c.OnInvalidOption != nil {
+			for _, err := range errs {
+				c.OnInvalidOption(name, err)
+			}
+		}
+		if len(errs) > 0 && c.Strict {
+			return template.HTML(""), errs[0]
+		}
+
+		ic, ok := findIcon(name)
+		if !ok {
+			if c.OnMissing != nil {
+				c.OnMissing(name)
+			}
+			if c.Strict {
+				return template.HTML(""), newErrUnknownIcon(name)
+			}
+		} else if c.OnDeprecated != nil && ic.isDeprecated(name) {
+			c.OnDeprecated(name, ic.replacement(name))
+		}
+
+		if !ok || unknownPreset {
+			ic = fallback
+			if ic == nil && c.Fallback != "" {
+				ic, _ = findIcon(c.Fallback)
+			}
+			if ic == nil {
+				return template.HTML(""), nil
+			}
+		}
+
+		cache := c.Cache
+		if cache == nil {
+			cache = globalCache.Load()
+		}
+
+		return renderCached(cache, ic, opts), nil
+	}
+}
+
+// presetArg returns the preset named by the first option argument of the
+// icon function. A leading string names a preset if it is configured, or
+// if it is not an option name and is the only argument or followed by
+// options: a map, an Options value or pointer, or inline key/value pairs
+// starting with an option name. Otherwise it starts the pairs, so a
+// misspelled option name is reported as an unknown option.
+func presetArg(presets map[string]Options, options []any) (string, bool) {
+	if len(options) == 0 {
+		return "", false
+	}
+	name, ok := options[0].(string)
+	if !ok {
+		return "", false
+	}
+	if _, ok := presets[name]; ok {
+		return name, true
+	}
+	if isOptionName(name) {
+		return "", false
+	}
+	if len(options) == 1 {
+		return name, true
+	}
+
+	switch next := options[1].(type) {
+	case Options, *Options:
+		return name, true
+	case string:
+		return name, isOptionName(next) && len(options)%2 != 0
+	default:
+		return name, next != nil && reflect.ValueOf(next).Kind() == reflect.Map
+	}
+}
+
 // Dict creates a map from key-value pairs.
 // Helper function for building option maps in templates.
 //
@@ -167,30 +514,3 @@ func Dict(values ...any) map[string]any {
 
 	return d
 }
-
-// buildSVG constructs an SVG string with the given parameters.
-// This is a helper function used by generated icon functions.
-func buildSVG(paths string, opts Options) template.HTML {
-	classAttr := ""
-	if opts.Class != "" {
-		classAttr = fmt.Sprintf(` class="%s"`, opts.Class)
-	}
-
-	svg := fmt.Sprintf(
-		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 24 24" fill="none" stroke="%s" stroke-width="%d" stroke-linecap="round" stroke-linejoin="round"%s>%s</svg>`,
-		opts.Size,
-		opts.Size,
-		opts.Color,
-		opts.StrokeWidth,
-		classAttr,
-		paths,
-	)
-
-	return template.HTML(svg)
-}
-
-// registerIcon registers an icon function in the global registry.
-// This is called by generated code in icons.go.
-func registerIcon(name string, fn func(opts ...Options) template.HTML) {
-	iconRegistry[name] = fn
-}
@@ -1,7 +1,10 @@
 package lucide
 
 import (
+	"bytes"
+	"errors"
 	"html/template"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -37,12 +40,48 @@ func TestIcon(t *testing.T) {
 			opts: map[string]any{"strokeWidth": 3},
 			want: `stroke-width="3"`,
 		},
+		{
+			name: "icon with fractional stroke width",
+			icon: "circle-x",
+			opts: map[string]any{"strokeWidth": 1.5},
+			want: `stroke-width="1.5"`,
+		},
+		{
+			name: "icon with absolute stroke width",
+			icon: "circle-x",
+			opts: map[string]any{"size": 48, "strokeWidth": 2, "absoluteStrokeWidth": true},
+			want: `stroke-width="1"`,
+		},
+		{
+			name: "icon filled",
+			icon: "heart",
+			opts: map[string]any{"filled": true},
+			want: `fill="currentColor"`,
+		},
+		{
+			name: "icon with square linecap",
+			icon: "minus",
+			opts: map[string]any{"strokeLinecap": "square"},
+			want: `stroke-linecap="square"`,
+		},
 		{
 			name: "icon with color",
 			icon: "circle-x",
 			opts: map[string]any{"color": "red"},
 			want: `stroke="red"`,
 		},
+		{
+			name: "icon with attrs",
+			icon: "circle-x",
+			opts: map[string]any{"attrs": map[string]any{"id": "close", "data-action": "dismiss"}},
+			want: `data-action="dismiss" id="close"`,
+		},
+		{
+			name: "icon with title",
+			icon: "circle-x",
+			opts: map[string]any{"title": "Close"},
+			want: `aria-label="Close"`,
+		},
 		{
 			name: "non-existent icon",
 			icon: "doesnt-exist",
@@ -67,6 +106,716 @@ func TestIcon(t *testing.T) {
 	}
 }
 
+func TestStrokeWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "default",
+			opts: Options{},
+			want: `stroke-width="2"`,
+		},
+		{
+			name: "fractional",
+			opts: Options{StrokeWidth: 1.5},
+			want: `stroke-width="1.5"`,
+		},
+		{
+			name: "absolute at default size",
+			opts: Options{StrokeWidth: 1.5, AbsoluteStrokeWidth: true},
+			want: `stroke-width="1.5"`,
+		},
+		{
+			name: "absolute scaled up",
+			opts: Options{Size: 48, AbsoluteStrokeWidth: true},
+			want: `stroke-width="1"`,
+		},
+		{
+			name: "absolute scaled down",
+			opts: Options{Size: 18, AbsoluteStrokeWidth: true},
+			want: `stroke-width="2.667"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(CircleX(tt.opts))
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("CircleX() = %v, want to contain %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillAndLineStyle(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{
+			name: "defaults",
+			opts: Options{},
+			want: []string{`fill="none"`, `stroke-linecap="round"`, `stroke-linejoin="round"`},
+		},
+		{
+			name: "filled uses stroke color",
+			opts: Options{Filled: true, Color: "red"},
+			want: []string{`fill="red"`, `stroke="red"`},
+		},
+		{
+			name: "filled defaults to currentColor",
+			opts: Options{Filled: true},
+			want: []string{`fill="currentColor"`},
+		},
+		{
+			name: "explicit fill wins",
+			opts: Options{Filled: true, Fill: "gold"},
+			want: []string{`fill="gold"`},
+		},
+		{
+			name: "square caps and miter joins",
+			opts: Options{StrokeLinecap: "square", StrokeLinejoin: "miter"},
+			want: []string{`stroke-linecap="square"`, `stroke-linejoin="miter"`},
+		},
+		{
+			name: "invalid line styles fall back",
+			opts: Options{StrokeLinecap: `"><script>`, StrokeLinejoin: "glue"},
+			want: []string{`stroke-linecap="round"`, `stroke-linejoin="round"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Heart(tt.opts))
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Heart() = %v, want to contain %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAccessibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "decorative by default",
+			opts:    Options{},
+			want:    []string{`aria-hidden="true"`},
+			notWant: []string{`role=`, `<title`},
+		},
+		{
+			name: "label",
+			opts: Options{Label: "Close dialog"},
+			want: []string{
+				`role="img"`,
+				`aria-label="Close dialog"`,
+				`aria-labelledby="lucide-title-`,
+				`><title id="lucide-title-`,
+				`">Close dialog</title>`,
+			},
+			notWant: []string{`aria-hidden`},
+		},
+		{
+			name: "label with id",
+			opts: Options{Label: "Close", Attrs: map[string]string{"id": "close"}},
+			want: []string{`aria-labelledby="close-title"`, `<title id="close-title">Close</title>`},
+		},
+		{
+			name:    "escaped label",
+			opts:    Options{Label: `<script>alert("x")</script>`},
+			want:    []string{`aria-label="&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;"`},
+			notWant: []string{`<script>`},
+		},
+		{
+			name:    "explicit aria attributes",
+			opts:    Options{Attrs: map[string]string{"aria-label": "Menu"}},
+			want:    []string{`aria-label="Menu"`},
+			notWant: []string{`aria-hidden`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(CircleX(tt.opts))
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("CircleX() = %v, want to contain %v", got, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("CircleX() = %v, want not to contain %v", got, notWant)
+				}
+			}
+		})
+	}
+
+	// Labelled icons rendered twice must not share a title id.
+	a := string(CircleX(Options{Label: "x"}))
+	b := string(CircleX(Options{Label: "x"}))
+	if a == b {
+		t.Errorf("labelled icons share a title id: %v", a)
+	}
+}
+
+// eventAttrPattern matches an emitted event handler attribute.
+var eventAttrPattern = regexp.MustCompile(`(?i)\son[a-z]*="`)
+
+func TestHostileInput(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "class breaking out of attribute",
+			opts: Options{Class: `x" onload="alert(1)`},
+			want: `class="x&#34; onload=&#34;alert(1)"`,
+		},
+		{
+			name: "class injecting markup",
+			opts: Options{Class: `"><script>alert(1)</script><svg class="`},
+			want: `class="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;&lt;svg class=&#34;"`,
+		},
+		{
+			name: "color breaking out of attribute",
+			opts: Options{Color: `red" onload="alert(1)`},
+			want: `stroke="currentColor"`,
+		},
+		{
+			name: "color injecting markup",
+			opts: Options{Color: `"><script>alert(1)</script>`},
+			want: `stroke="currentColor"`,
+		},
+		{
+			name: "fill breaking out of attribute",
+			opts: Options{Fill: `red" onload="alert(1)`},
+			want: `fill="none"`,
+		},
+		{
+			name: "attribute value breaking out",
+			opts: Options{Attrs: map[string]string{"data-x": `"><script>alert(1)</script>`}},
+			want: `data-x="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"`,
+		},
+		{
+			name: "attribute name injection",
+			opts: Options{Attrs: map[string]string{`onload="alert(1)" x`: "y"}},
+			want: `aria-hidden="true">`,
+		},
+		{
+			name: "event handler attribute",
+			opts: Options{Attrs: map[string]string{"onload": "alert(document.domain)", "OnClick": "alert(1)"}},
+			want: `aria-hidden="true">`,
+		},
+		{
+			name: "label injecting markup",
+			opts: Options{Label: `</title><script>alert(1)</script>`},
+			want: `&lt;/title&gt;&lt;script&gt;alert(1)&lt;/script&gt;</title>`,
+		},
+		{
+			name: "width breaking out of attribute",
+			opts: Options{Width: `1" onload="alert(1)`},
+			want: `width="24"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(CircleX(tt.opts))
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("CircleX() = %v, want to contain %v", got, tt.want)
+			}
+			if strings.Contains(got, "<script") || eventAttrPattern.MatchString(got) {
+				t.Errorf("CircleX() = %v, contains injected markup", got)
+			}
+		})
+	}
+}
+
+func TestHostileTemplateInput(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(FuncMap(&Config{Strict: true})).Parse(
+		`{{ lucide "circle-x" (dict "color" .) }}`,
+	))
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, `red" onload="alert(1)`)
+	var invalid *ErrInvalidOption
+	if !errors.As(err, &invalid) || invalid.Option != "color" {
+		t.Errorf("Execute() error = %v, want *ErrInvalidOption for color", err)
+	}
+	if strings.Contains(buf.String(), "onload") {
+		t.Errorf("Execute() = %v, contains injected markup", buf.String())
+	}
+}
+
+func TestIconByName(t *testing.T) {
+	got := IconByName(NameCircleX, Options{Size: 32})
+	if got != CircleX(Options{Size: 32}) {
+		t.Errorf("IconByName() = %v, want CircleX()", got)
+	}
+
+	if got := IconByName(NameAlarmCheck); got != AlarmClockCheck() {
+		t.Errorf("IconByName() with alias = %v, want AlarmClockCheck()", got)
+	}
+
+	if got := Icon(NameMenu); got != Menu() {
+		t.Errorf("Icon() with Name = %v, want Menu()", got)
+	}
+
+	tmpl := template.Must(template.New("test").Funcs(FuncMap()).Parse(`{{ lucide .Name (dict "size" 32) }}`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Name Name }{NameMenu}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := buf.String(); got != string(Menu(Options{Size: 32})) {
+		t.Errorf("Execute() with Name = %v, want Menu()", got)
+	}
+
+	if got := IconByName(Name("doesnt-exist")); got != "" {
+		t.Errorf("IconByName() = %v, want empty", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	got, err := Render("circle-x", Options{Size: 32})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(got), `width="32"`) {
+		t.Errorf("Render() = %v, want to contain width=\"32\"", got)
+	}
+	if !strings.Contains(string(got), `stroke="currentColor"`) {
+		t.Errorf("Render() = %v, want default stroke color", got)
+	}
+
+	got, err = Render("doesnt-exist")
+	if got != "" {
+		t.Errorf("Render() = %v, want empty", got)
+	}
+
+	var unknown *ErrUnknownIcon
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Render() error = %v, want *ErrUnknownIcon", err)
+	}
+	if unknown.Name != "doesnt-exist" {
+		t.Errorf("ErrUnknownIcon.Name = %q, want %q", unknown.Name, "doesnt-exist")
+	}
+}
+
+func TestFuncMapStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		tmpl    string
+		wantErr bool
+	}{
+		{
+			name: "lenient unknown icon",
+			tmpl: `{{ lucide "doesnt-exist" }}`,
+		},
+		{
+			name:    "strict unknown icon",
+			config:  &Config{Strict: true},
+			tmpl:    `{{ lucide "doesnt-exist" }}`,
+			wantErr: true,
+		},
+		{
+			name:   "strict known icon",
+			config: &Config{Strict: true},
+			tmpl:   `{{ lucide "circle-x" (dict "size" 32) }}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(FuncMap(tt.config)).Parse(tt.tmpl))
+
+			var buf bytes.Buffer
+			err := tmpl.Execute(&buf, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var unknown *ErrUnknownIcon
+				if !errors.As(err, &unknown) {
+					t.Errorf("Execute() error = %v, want *ErrUnknownIcon", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFuncMapFallback(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		tmpl   string
+		want   string
+	}{
+		{
+			name:   "fallback icon name",
+			config: &Config{Fallback: "circle-help"},
+			tmpl:   `{{ lucide "doesnt-exist" (dict "size" 16) }}`,
+			want:   string(CircleHelp(Options{Size: 16})),
+		},
+		{
+			name:   "fallback markup",
+			config: &Config{Fallback: `<svg viewBox="0 0 24 24"><circle cx="12" cy="12" r="4" /></svg>`},
+			tmpl:   `{{ lucide "doesnt-exist" }}`,
+			want:   string(buildSVG(`<circle cx="12" cy="12" r="4" />`, Options{})),
+		},
+		{
+			name:   "unknown fallback",
+			config: &Config{Fallback: "also-doesnt-exist"},
+			tmpl:   `{{ lucide "doesnt-exist" }}`,
+			want:   "",
+		},
+		{
+			name:   "known icon ignores fallback",
+			config: &Config{Fallback: "circle-help"},
+			tmpl:   `{{ lucide "circle-x" }}`,
+			want:   string(CircleX()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(FuncMap(tt.config)).Parse(tt.tmpl))
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, nil); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncMapHooks(t *testing.T) {
+	var missing []string
+	var deprecated [][2]string
+	cfg := &Config{
+		Fallback:  "circle-help",
+		OnMissing: func(name string) { missing = append(missing, name) },
+		OnDeprecated: func(name, replacement string) {
+			deprecated = append(deprecated, [2]string{name, replacement})
+		},
+	}
+
+	tmpl := template.Must(template.New("test").Funcs(FuncMap(cfg)).Parse(
+		`{{ lucide "doesnt-exist" }}{{ lucide "alarm-check" }}{{ lucide "alarm-clock-check" }}`,
+	))
+	if err := tmpl.Execute(&bytes.Buffer{}, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(missing) != 1 || missing[0] != "doesnt-exist" {
+		t.Errorf("OnMissing calls = %v, want [doesnt-exist]", missing)
+	}
+	if len(deprecated) != 1 || deprecated[0] != [2]string{"alarm-check", "alarm-clock-check"} {
+		t.Errorf("OnDeprecated calls = %v, want [[alarm-check alarm-clock-check]]", deprecated)
+	}
+
+	cfg.Strict = true
+	tmpl = template.Must(template.New("test").Funcs(FuncMap(cfg)).Parse(`{{ lucide "doesnt-exist" }}`))
+	if err := tmpl.Execute(&bytes.Buffer{}, nil); err == nil {
+		t.Errorf("Execute() error = nil, want error in strict mode despite Fallback")
+	}
+	if len(missing) != 2 {
+		t.Errorf("OnMissing calls = %v, want it called in strict mode too", missing)
+	}
+}
+
+func TestFuncMapInvalidOptions(t *testing.T) {
+	var reported []error
+	cfg := &Config{
+		OnInvalidOption: func(name string, err error) {
+			if name != "circle-x" {
+				t.Errorf("OnInvalidOption name = %q, want circle-x", name)
+			}
+			reported = append(reported, err)
+		},
+	}
+
+	tmpl := template.Must(template.New("test").Funcs(FuncMap(cfg)).Parse(
+		`{{ lucide "circle-x" (dict "sise" 32 "size" "48") }}`,
+	))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `width="48"`) {
+		t.Errorf("Execute() = %v, want valid options applied", buf.String())
+	}
+
+	var unknown *ErrUnknownOption
+	if len(reported) != 1 || !errors.As(reported[0], &unknown) || unknown.Option != "sise" {
+		t.Errorf("OnInvalidOption errors = %v, want unknown option sise", reported)
+	}
+
+	cfg.Strict = true
+	tmpl = template.Must(template.New("test").Funcs(FuncMap(cfg)).Parse(`{{ lucide "circle-x" (dict "sise" 32) }}`))
+	if err := tmpl.Execute(&buf, nil); !errors.As(err, &unknown) {
+		t.Errorf("Execute() error = %v, want *ErrUnknownOption in strict mode", err)
+	}
+}
+
+func TestFuncMapDefaults(t *testing.T) {
+	cfg := &Config{Defaults: Options{
+		Class:       "icon",
+		StrokeWidth: 1.5,
+		Width:       "1em",
+		Attrs:       map[string]string{"data-a": "1"},
+	}}
+
+	tests := []struct {
+		name string
+		tmpl string
+		want Options
+	}{
+		{
+			name: "defaults only",
+			tmpl: `{{ lucide "circle-x" }}`,
+			want: Options{Class: "icon", StrokeWidth: 1.5, Width: "1em", Attrs: map[string]string{"data-a": "1"}},
+		},
+		{
+			name: "class appended",
+			tmpl: `{{ lucide "circle-x" (dict "class" "big" "strokeWidth" 3) }}`,
+			want: Options{Class: "icon big", StrokeWidth: 3, Width: "1em", Attrs: map[string]string{"data-a": "1"}},
+		},
+		{
+			name: "size replaces default width",
+			tmpl: `{{ lucide "circle-x" (dict "size" 32 "attrs" (dict "data-b" 2)) }}`,
+			want: Options{Class: "icon", StrokeWidth: 1.5, Size: 32, Attrs: map[string]string{"data-a": "1", "data-b": "2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(FuncMap(cfg)).Parse(tt.tmpl))
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, nil); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if want := string(CircleX(tt.want)); buf.String() != want {
+				t.Errorf("Execute() = %v, want %v", buf.String(), want)
+			}
+		})
+	}
+
+	if got, want := Icon("circle-x"), CircleX(); got != want {
+		t.Errorf("Icon() = %v, want built-in defaults %v", got, want)
+	}
+}
+
+func TestFuncMapPresets(t *testing.T) {
+	cfg := &Config{
+		Defaults: Options{Class: "icon"},
+		Presets: map[string]Options{
+			"sm":    {Size: 16, StrokeWidth: 1.5, Class: "icon-sm"},
+			"lg":    {Size: 32, StrokeWidth: 2.5, Class: "icon-lg"},
+			"solid": {Filled: true, OmitSize: true, Rotate: 90, Label: "Close"},
+		},
+		Fallback: "circle-help",
+	}
+
+	tests := []struct {
+		name    string
+		config  *Config
+		tmpl    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "preset",
+			config: cfg,
+			tmpl:   `{{ lucide "circle-x" "lg" }}`,
+			want:   string(CircleX(Options{Size: 32, StrokeWidth: 2.5, Class: "icon icon-lg"})),
+		},
+		{
+			name:   "preset with overrides",
+			config: cfg,
+			tmpl:   `{{ lucide "circle-x" "sm" (dict "class" "nav" "color" "red") }}`,
+			want:   string(CircleX(Options{Size: 16, StrokeWidth: 1.5, Class: "icon icon-sm nav", Color: "red"})),
+		},
+		{
+			name:   "preset turned off by zero values",
+			config: cfg,
+			tmpl:   `{{ lucide "circle-x" "solid" (dict "filled" false "omitSize" false "rotate" 0 "label" "") }}`,
+			want:   string(CircleX(Options{Class: "icon"})),
+		},
+		{
+			name:   "unknown preset renders fallback",
+			config: cfg,
+			tmpl:   `{{ lucide "circle-x" "xxl" }}`,
+			want:   string(CircleHelp(Options{Class: "icon"})),
+		},
+		{
+			name:   "unknown preset with dict renders fallback",
+			config: cfg,
+			tmpl:   `{{ lucide "circle-x" "xxl" (dict "class" "nav") }}`,
+			want:   string(CircleHelp(Options{Class: "icon nav"})),
+		},
+		{
+			name:    "unknown preset in strict mode",
+			config:  &Config{Strict: true, Presets: cfg.Presets},
+			tmpl:    `{{ lucide "circle-x" "xxl" }}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown preset with dict in strict mode",
+			config:  &Config{Strict: true, Presets: cfg.Presets},
+			tmpl:    `{{ lucide "circle-x" "xxl" (dict "class" "nav") }}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(FuncMap(tt.config)).Parse(tt.tmpl))
+
+			var buf bytes.Buffer
+			err := tmpl.Execute(&buf, nil)
+			if tt.wantErr {
+				var unknown *ErrUnknownPreset
+				if !errors.As(err, &unknown) || unknown.Name != "xxl" {
+					t.Errorf("Execute() error = %v, want *ErrUnknownPreset", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Execute() = %v, want %v", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncMapInlinePairs(t *testing.T) {
+	cfg := &Config{
+		Presets: map[string]Options{"lg": {Size: 32}},
+		Strict:  true,
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		want    Options
+		wantErr string
+	}{
+		{
+			name: "pairs",
+			tmpl: `{{ lucide "circle-x" "size" 16 "class" "x" }}`,
+			want: Options{Size: 16, Class: "x"},
+		},
+		{
+			name: "preset and pairs",
+			tmpl: `{{ lucide "circle-x" "lg" "class" "x" }}`,
+			want: Options{Size: 32, Class: "x"},
+		},
+		{
+			name: "dict and pairs",
+			tmpl: `{{ lucide "circle-x" (dict "size" 16 "class" "a") "class" "b" }}`,
+			want: Options{Size: 16, Class: "a b"},
+		},
+		{
+			name: "single map still works",
+			tmpl: `{{ lucide "circle-x" (dict "size" 16) }}`,
+			want: Options{Size: 16},
+		},
+		{
+			name:    "odd number of arguments",
+			tmpl:    `{{ lucide "circle-x" "size" 16 "class" }}`,
+			wantErr: `lucide: option "class" has no value`,
+		},
+		{
+			name:    "non-string key",
+			tmpl:    `{{ lucide "circle-x" "size" 16 32 "x" }}`,
+			wantErr: `lucide: option name 32 is a int, want a string`,
+		},
+		{
+			name:    "unknown preset before pairs",
+			tmpl:    `{{ lucide "circle-x" "xxl" "size" 16 }}`,
+			wantErr: `lucide: unknown preset "xxl"`,
+		},
+		{
+			name:    "misspelled option name",
+			tmpl:    `{{ lucide "circle-x" "sise" 32 }}`,
+			wantErr: `lucide: unknown option "sise"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(FuncMap(cfg)).Parse(tt.tmpl))
+
+			var buf bytes.Buffer
+			err := tmpl.Execute(&buf, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if want := string(CircleX(tt.want)); buf.String() != want {
+				t.Errorf("Execute() = %v, want %v", buf.String(), want)
+			}
+		})
+	}
+
+	if got, want := Icon("circle-x", "size", 16), CircleX(Options{Size: 16}); got != want {
+		t.Errorf("Icon() with pairs = %v, want %v", got, want)
+	}
+
+	var errs []error
+	tmpl := template.Must(template.New("test").Funcs(FuncMap(&Config{
+		Presets:         cfg.Presets,
+		Fallback:        "circle-help",
+		OnInvalidOption: func(_ string, err error) { errs = append(errs, err) },
+	})).Parse(`{{ lucide "circle-x" "sise" 32 }}`))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := string(CircleX()); buf.String() != want {
+		t.Errorf("Execute() with misspelled option = %v, want %v", buf.String(), want)
+	}
+	var unknown *ErrUnknownOption
+	if len(errs) != 1 || !errors.As(errs[0], &unknown) || unknown.Option != "sise" {
+		t.Errorf("OnInvalidOption errors = %v, want *ErrUnknownOption for sise", errs)
+	}
+}
+
+func TestIconOptionsStruct(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(FuncMap()).Parse(`{{ lucide "circle-x" . }}`))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, Options{Size: 32, Class: "big"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := string(CircleX(Options{Size: 32, Class: "big"})); buf.String() != want {
+		t.Errorf("Execute() = %v, want %v", buf.String(), want)
+	}
+}
+
 func TestFuncMap(t *testing.T) {
 	tests := []struct {
 		name   string
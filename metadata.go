@@ -0,0 +1,121 @@
+package lucide
+
+import "slices"
+
+// IconMetadata describes an icon using the metadata shipped with each
+// Lucide release.
+type IconMetadata struct {
+	// Name is the canonical icon name
+	Name string
+
+	// Aliases are the alternative names of the icon
+	Aliases []string
+
+	// Tags are search keywords, e.g. "cancel" and "close" for "circle-x"
+	Tags []string
+
+	// Categories are the lucide.dev categories of the icon, e.g. "shapes"
+	Categories []string
+
+	// Contributors are the GitHub usernames of the icon authors
+	Contributors []string
+
+	// Deprecated reports whether the requested name is deprecated. For an
+	// alias this is the deprecation status of the alias itself.
+	Deprecated bool
+
+	// DeprecationReason is the upstream reason code, e.g. "alias.name"
+	DeprecationReason string
+
+	// MirrorRTL reports whether the icon is direction-sensitive and is
+	// mirrored when rendered with Options.RTL
+	MirrorRTL bool
+}
+
+// Metadata returns the metadata of an icon by name or alias. Custom icons
+// added with Register have no metadata beyond their name.
+//
+// Usage:
+//
+//	meta, ok := lucide.Metadata("circle-x")
+//	if ok {
+//	    fmt.Println(meta.Tags, meta.Categories)
+//	}
+func Metadata(name string) (IconMetadata, bool) {
+	ic, ok := findIcon(name)
+	if !ok {
+		return IconMetadata{}, false
+	}
+
+	meta := IconMetadata{
+		Name:              ic.name,
+		Tags:              slices.Clone(ic.tags),
+		Categories:        slices.Clone(ic.categories),
+		Contributors:      slices.Clone(ic.contributors),
+		Deprecated:        ic.deprecated,
+		DeprecationReason: ic.deprecationReason,
+		MirrorRTL:         ic.mirrorRTL,
+	}
+
+	for _, alias := range ic.aliases {
+		meta.Aliases = append(meta.Aliases, alias.name)
+		if alias.name == name {
+			meta.Deprecated = ic.deprecated || alias.deprecated
+			if alias.deprecationReason != "" {
+				meta.DeprecationReason = alias.deprecationReason
+			}
+		}
+	}
+
+	return meta, true
+}
+
+// IsDeprecated reports whether name is a deprecated icon or alias. This
+// lets template-based usages, which the compiler never sees, be detected at
+// runtime.
+//
+// Usage:
+//
+//	if lucide.IsDeprecated("alarm-check") {
+//	    replacement, _ := lucide.Replacement("alarm-check")
+//	    log.Printf("icon alarm-check is deprecated, use %s", replacement)
+//	}
+func IsDeprecated(name string) bool {
+	ic, ok := findIcon(name)
+	return ok && ic.isDeprecated(name)
+}
+
+// Replacement returns the canonical icon name to use instead of a deprecated
+// alias. It returns false if name is unknown, not deprecated, or a deprecated
+// icon without a replacement.
+func Replacement(name string) (string, bool) {
+	ic, ok := findIcon(name)
+	if !ok {
+		return "", false
+	}
+	replacement := ic.replacement(name)
+	return replacement, replacement != ""
+}
+
+// isDeprecated reports whether name, the icon name or one of its aliases,
+// is deprecated. Every alias of a deprecated icon is deprecated too.
+func (ic *icon) isDeprecated(name string) bool {
+	if ic.deprecated {
+		return true
+	}
+	for _, alias := range ic.aliases {
+		if alias.name == name {
+			return alias.deprecated
+		}
+	}
+	return false
+}
+
+// replacement returns the canonical name to use instead of name, or "" if
+// name is not a deprecated alias of a current icon.
+func (ic *icon) replacement(name string) string {
+	if ic.name == name || ic.deprecated || !ic.isDeprecated(name) {
+		return ""
+	}
+	return ic.name
+}
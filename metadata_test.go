@@ -0,0 +1,113 @@
+package lucide
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMetadata(t *testing.T) {
+	meta, ok := Metadata("alarm-clock-check")
+	if !ok {
+		t.Fatalf("Metadata() ok = false, want true")
+	}
+	if meta.Name != "alarm-clock-check" {
+		t.Errorf("Metadata().Name = %q, want %q", meta.Name, "alarm-clock-check")
+	}
+	if !slices.Contains(meta.Aliases, "alarm-check") {
+		t.Errorf("Metadata().Aliases = %v, want to contain alarm-check", meta.Aliases)
+	}
+	if meta.Deprecated {
+		t.Errorf("Metadata().Deprecated = true, want false")
+	}
+
+	meta, ok = Metadata("alarm-check")
+	if !ok {
+		t.Fatalf("Metadata() ok = false, want true")
+	}
+	if meta.Name != "alarm-clock-check" {
+		t.Errorf("Metadata().Name = %q, want canonical name", meta.Name)
+	}
+	if !meta.Deprecated || meta.DeprecationReason != "alias.name" {
+		t.Errorf("Metadata() deprecation = %v %q, want true %q", meta.Deprecated, meta.DeprecationReason, "alias.name")
+	}
+
+	if _, ok := Metadata("doesnt-exist"); ok {
+		t.Errorf("Metadata() ok = true for unknown icon")
+	}
+
+	restoreRegistry(t, "test:old", "test:old-alias")
+	registerIcon(&icon{
+		name:              "test:old",
+		aliases:           []iconAlias{{name: "test:old-alias"}},
+		deprecated:        true,
+		deprecationReason: "icon.brand",
+	})
+
+	meta, ok = Metadata("test:old-alias")
+	if !ok {
+		t.Fatalf("Metadata() ok = false, want true")
+	}
+	if !meta.Deprecated || meta.DeprecationReason != "icon.brand" {
+		t.Errorf("Metadata() deprecation = %v %q, want true %q", meta.Deprecated, meta.DeprecationReason, "icon.brand")
+	}
+}
+
+func TestMetadataFields(t *testing.T) {
+	restoreRegistry(t, "test:meta")
+
+	ic := &icon{
+		name:         "test:meta",
+		paths:        "<path />",
+		tags:         []string{"round"},
+		categories:   []string{"shapes"},
+		contributors: []string{"alice"},
+	}
+	registerIcon(ic)
+
+	meta, ok := Metadata("test:meta")
+	if !ok {
+		t.Fatalf("Metadata() ok = false, want true")
+	}
+	if !slices.Equal(meta.Tags, ic.tags) || !slices.Equal(meta.Categories, ic.categories) || !slices.Equal(meta.Contributors, ic.contributors) {
+		t.Errorf("Metadata() = %+v, want fields of %+v", meta, ic)
+	}
+
+	meta.Tags[0] = "changed"
+	if ic.tags[0] != "round" {
+		t.Errorf("modifying Metadata().Tags changed the registry")
+	}
+}
+
+func TestIsDeprecated(t *testing.T) {
+	restoreRegistry(t, "test:old", "test:old-alias")
+	registerIcon(&icon{
+		name:       "test:old",
+		aliases:    []iconAlias{{name: "test:old-alias"}},
+		deprecated: true,
+	})
+
+	tests := []struct {
+		name            string
+		wantDeprecated  bool
+		wantReplacement string
+	}{
+		{"alarm-check", true, "alarm-clock-check"},
+		{"alarm-clock-check", false, ""},
+		{"test:old", true, ""},
+		{"test:old-alias", true, ""},
+		{"doesnt-exist", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDeprecated(tt.name); got != tt.wantDeprecated {
+				t.Errorf("IsDeprecated(%q) = %v, want %v", tt.name, got, tt.wantDeprecated)
+			}
+
+			got, ok := Replacement(tt.name)
+			if got != tt.wantReplacement || ok != (tt.wantReplacement != "") {
+				t.Errorf("Replacement(%q) = %q, %v, want %q", tt.name, got, ok, tt.wantReplacement)
+			}
+		})
+	}
+}
@@ -0,0 +1,574 @@
+package lucide
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cssLengthPattern matches unitless numbers and CSS lengths with an absolute,
+// font-relative, viewport or percentage unit.
+var cssLengthPattern = regexp.MustCompile(`^(?:\d+(?:\.\d+)?|\.\d+)(?:px|em|rem|ex|ch|lh|rlh|vw|vh|vmin|vmax|cm|mm|q|in|pt|pc|%)?$`)
+
+// isCSSLength reports whether s is a valid value for the width or height
+// attribute.
+func isCSSLength(s string) bool {
+	return cssLengthPattern.MatchString(strings.ToLower(s))
+}
+
+// pixels returns the pixel value of a unitless or px length.
+func pixels(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s, "px"), 64)
+	return f, err == nil
+}
+
+// lineCaps are the valid stroke-linecap values.
+var lineCaps = map[string]bool{
+	"butt":   true,
+	"round":  true,
+	"square": true,
+}
+
+// lineJoins are the valid stroke-linejoin values.
+var lineJoins = map[string]bool{
+	"arcs":       true,
+	"bevel":      true,
+	"miter":      true,
+	"miter-clip": true,
+	"round":      true,
+}
+
+// Validate reports an *ErrInvalidOption for the first option value that
+// cannot be rendered. Rendering functions that do not return errors fall
+// back to the default for invalid values instead.
+func (o Options) Validate() error {
+	if o.Size < 0 {
+		return &ErrInvalidOption{Option: "size", Value: o.Size}
+	}
+	if o.StrokeWidth < 0 || math.IsNaN(o.StrokeWidth) || math.IsInf(o.StrokeWidth, 0) {
+		return &ErrInvalidOption{Option: "strokeWidth", Value: o.StrokeWidth}
+	}
+	if o.Color != "" && !isCSSColor(o.Color) {
+		return &ErrInvalidOption{Option: "color", Value: o.Color}
+	}
+	if o.Fill != "" && !isCSSColor(o.Fill) {
+		return &ErrInvalidOption{Option: "fill", Value: o.Fill}
+	}
+	if o.Width != "" && !isCSSLength(o.Width) {
+		return &ErrInvalidOption{Option: "width", Value: o.Width}
+	}
+	if o.Height != "" && !isCSSLength(o.Height) {
+		return &ErrInvalidOption{Option: "height", Value: o.Height}
+	}
+	if o.StrokeLinecap != "" && !lineCaps[o.StrokeLinecap] {
+		return &ErrInvalidOption{Option: "strokeLinecap", Value: o.StrokeLinecap}
+	}
+	if o.StrokeLinejoin != "" && !lineJoins[o.StrokeLinejoin] {
+		return &ErrInvalidOption{Option: "strokeLinejoin", Value: o.StrokeLinejoin}
+	}
+	if math.IsNaN(o.Rotate) || math.IsInf(o.Rotate, 0) {
+		return &ErrInvalidOption{Option: "rotate", Value: o.Rotate}
+	}
+	if _, ok := animations[o.Animation]; o.Animation != "" && !ok {
+		return &ErrInvalidOption{Option: "animation", Value: o.Animation}
+	}
+	if o.AnimationDuration < 0 || math.IsNaN(o.AnimationDuration) || math.IsInf(o.AnimationDuration, 0) {
+		return &ErrInvalidOption{Option: "animationDuration", Value: o.AnimationDuration}
+	}
+	return nil
+}
+
+// size returns Size, or the default of 24 if unset or negative.
+func (o Options) size() int {
+	if o.Size <= 0 {
+		return 24
+	}
+	return o.Size
+}
+
+// lengths returns the valid CSS lengths set in Width and Height. Empty
+// values mean the length falls back to Size.
+func (o Options) lengths() (width, height string) {
+	if o.Width != "" && isCSSLength(o.Width) {
+		width = strings.ToLower(o.Width)
+	}
+	if o.Height != "" && isCSSLength(o.Height) {
+		height = strings.ToLower(o.Height)
+	}
+	return width, height
+}
+
+// rotation returns Rotate normalized to the range (-360, 360), or 0 if it
+// is not finite.
+func (o Options) rotation() float64 {
+	if math.IsNaN(o.Rotate) || math.IsInf(o.Rotate, 0) {
+		return 0
+	}
+	return math.Mod(o.Rotate, 360)
+}
+
+// transformed reports whether the paths need a transform for the Rotate,
+// FlipX or FlipY options.
+func (o Options) transformed() bool {
+	return o.rotation() != 0 || o.FlipX || o.FlipY
+}
+
+// strokeWidth returns the stroke width in viewBox units: StrokeWidth, or the
+// default of 2 if unset or invalid, scaled against the rendered width when
+// AbsoluteStrokeWidth is set.
+func (o Options) strokeWidth() float64 {
+	strokeWidth := o.StrokeWidth
+	if strokeWidth <= 0 || math.IsNaN(strokeWidth) || math.IsInf(strokeWidth, 0) {
+		strokeWidth = 2
+	}
+
+	if o.AbsoluteStrokeWidth {
+		px, ok := float64(o.size()), true
+		if width, _ := o.lengths(); width != "" {
+			px, ok = pixels(width)
+		}
+		if ok && px > 0 {
+			strokeWidth = strokeWidth * 24 / px
+		}
+	}
+
+	return strokeWidth
+}
+
+// parseOptions converts the option arguments of the icon function into
+// Options. Each argument is a map of option names to values, such as the
+// result of dict or any other map with string keys, or an Options value or
+// pointer; later arguments are merged over earlier ones. The arguments may
+// end with inline key/value pairs, see splitPairs. Values are coerced
+// where unambiguous, so numbers may be of any numeric kind or a numeric
+// string. Unknown keys and invalid values are skipped and reported as an
+// *ErrUnknownOption or *ErrInvalidOption, in argument and key order.
+func parseOptions(options ...any) (Options, []error) {
+	return mergeOptions(defaultOptions, options)
+}
+
+// defaultOptions are the options of the icon function when none are passed.
+var defaultOptions = Options{
+	Size:        24,
+	Color:       "currentColor",
+	StrokeWidth: 2,
+}
+
+// mergeOptions merges the option arguments of the icon function over opts,
+// see parseOptions.
+func mergeOptions(opts Options, options []any) (Options, []error) {
+	var errs []error
+	options, err := splitPairs(options)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, arg := range options {
+		switch v := arg.(type) {
+		case nil:
+		case Options:
+			opts = opts.merge(v)
+		case *Options:
+			if v != nil {
+				opts = opts.merge(*v)
+			}
+		default:
+			m, ok := toStringMap(arg)
+			if !ok {
+				errs = append(errs, &ErrInvalidOption{Option: "options", Value: arg})
+				continue
+			}
+
+			known := 0
+			for _, key := range optionNames {
+				value, ok := m[key]
+				if !ok {
+					continue
+				}
+				known++
+				if err := opts.set(key, value); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			if known < len(m) {
+				for _, key := range slices.Sorted(maps.Keys(m)) {
+					if !isOptionName(key) {
+						errs = append(errs, &ErrUnknownOption{Option: key})
+					}
+				}
+			}
+		}
+	}
+
+	return opts, errs
+}
+
+// splitPairs replaces the trailing inline key/value pairs of the option
+// arguments of the icon function, as in {{ lucide "play" "size" 32 }}, with
+// a map built by Dict. Pairs start at the first string argument. Unlike
+// Dict, it reports a non-string key or a key without a value.
+func splitPairs(options []any) ([]any, error) {
+	k := slices.IndexFunc(options, func(v any) bool {
+		_, ok := v.(string)
+		return ok
+	})
+	if k < 0 {
+		return options, nil
+	}
+
+	pairs := options[k:]
+	var err error
+	for i := 0; i < len(pairs); i += 2 {
+		if _, ok := pairs[i].(string); !ok {
+			err = fmt.Errorf("lucide: option name %v is a %T, want a string", pairs[i], pairs[i])
+			break
+		}
+	}
+	if err == nil && len(pairs)%2 != 0 {
+		err = fmt.Errorf("lucide: option %q has no value", pairs[len(pairs)-1])
+	}
+
+	return append(options[:k:k], Dict(pairs...)), err
+}
+
+// isOptionName reports whether name is an option name accepted in template
+// dicts.
+func isOptionName(name string) bool {
+	return slices.Contains(optionNames, name)
+}
+
+// optionNames are the option names accepted in template dicts, in the order
+// they are applied. "title" comes after "label" and takes precedence.
+var optionNames = []string{
+	"size", "width", "height", "omitSize",
+	"color", "strokeWidth", "absoluteStrokeWidth",
+	"fill", "filled", "strokeLinecap", "strokeLinejoin",
+	"class", "label", "title", "attrs",
+	"rotate", "flipX", "flipY", "rtl",
+	"animation", "animationDuration", "nonce",
+}
+
+// set sets the option named key, as used in template dicts, to value, even
+// if it is the zero value, so dicts can turn off options of presets and
+// defaults. Like merge, setting a numeric size resets Width and Height,
+// classes are appended and attributes are merged. Invalid values leave o
+// unchanged.
+func (o *Options) set(key string, value any) error {
+	switch key {
+	case "size":
+		if n, ok := toInt(value); ok && n >= 0 {
+			o.Size = n
+			o.Width, o.Height = "", ""
+		} else if length, ok := toLength(value); ok {
+			o.Width, o.Height = length, length
+		} else {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+	case "width", "height":
+		length, ok := toLength(value)
+		if !ok {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		if key == "width" {
+			o.Width = length
+		} else {
+			o.Height = length
+		}
+	case "color", "fill":
+		color, ok := toString(value)
+		if !ok || !isCSSColor(color) {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		if key == "color" {
+			o.Color = color
+		} else {
+			o.Fill = color
+		}
+	case "strokeWidth":
+		width, ok := toFloat(value)
+		if !ok || width < 0 {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.StrokeWidth = width
+	case "animation":
+		animation, ok := toString(value)
+		if _, known := animations[animation]; !ok || (animation != "" && !known) {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.Animation = animation
+	case "animationDuration":
+		dur, ok := toFloat(value)
+		if !ok || dur < 0 {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.AnimationDuration = dur
+	case "nonce":
+		nonce, ok := toString(value)
+		if !ok {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.Nonce = nonce
+	case "rotate":
+		rotate, ok := toFloat(value)
+		if !ok {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.Rotate = rotate
+	case "omitSize", "absoluteStrokeWidth", "filled", "flipX", "flipY", "rtl":
+		b, ok := toBool(value)
+		if !ok {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		switch key {
+		case "omitSize":
+			o.OmitSize = b
+		case "absoluteStrokeWidth":
+			o.AbsoluteStrokeWidth = b
+		case "filled":
+			o.Filled = b
+		case "flipX":
+			o.FlipX = b
+		case "flipY":
+			o.FlipY = b
+		case "rtl":
+			o.RTL = b
+		}
+	case "strokeLinecap":
+		linecap, ok := toString(value)
+		if !ok || !lineCaps[linecap] {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.StrokeLinecap = linecap
+	case "strokeLinejoin":
+		linejoin, ok := toString(value)
+		if !ok || !lineJoins[linejoin] {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.StrokeLinejoin = linejoin
+	case "class":
+		class, ok := toString(value)
+		if !ok {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		*o = o.merge(Options{Class: class})
+	case "label", "title":
+		label, ok := toString(value)
+		if !ok {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		o.Label = label
+	case "attrs":
+		attrs, ok := parseAttrs(value)
+		if !ok {
+			return &ErrInvalidOption{Option: key, Value: value}
+		}
+		*o = o.merge(Options{Attrs: attrs})
+	default:
+		return &ErrUnknownOption{Option: key}
+	}
+
+	return nil
+}
+
+// merge returns o with the fields set in over applied on top. Setting Size
+// resets the Width and Height of o, classes are appended and attributes are
+// merged, with over taking precedence.
+func (o Options) merge(over Options) Options {
+	if over.Size != 0 {
+		o.Size = over.Size
+		o.Width, o.Height = "", ""
+	}
+	if over.Width != "" {
+		o.Width = over.Width
+	}
+	if over.Height != "" {
+		o.Height = over.Height
+	}
+	if over.OmitSize {
+		o.OmitSize = true
+	}
+	if over.Color != "" {
+		o.Color = over.Color
+	}
+	if over.StrokeWidth != 0 {
+		o.StrokeWidth = over.StrokeWidth
+	}
+	if over.AbsoluteStrokeWidth {
+		o.AbsoluteStrokeWidth = true
+	}
+	if over.Fill != "" {
+		o.Fill = over.Fill
+	}
+	if over.Filled {
+		o.Filled = true
+	}
+	if over.StrokeLinecap != "" {
+		o.StrokeLinecap = over.StrokeLinecap
+	}
+	if over.StrokeLinejoin != "" {
+		o.StrokeLinejoin = over.StrokeLinejoin
+	}
+	if over.Class != "" {
+		if o.Class != "" {
+			o.Class += " " + over.Class
+		} else {
+			o.Class = over.Class
+		}
+	}
+	if len(over.Attrs) > 0 {
+		attrs := make(map[string]string, len(o.Attrs)+len(over.Attrs))
+		maps.Copy(attrs, o.Attrs)
+		maps.Copy(attrs, over.Attrs)
+		o.Attrs = attrs
+	}
+	if over.Label != "" {
+		o.Label = over.Label
+	}
+	if over.Rotate != 0 {
+		o.Rotate = over.Rotate
+	}
+	if over.FlipX {
+		o.FlipX = true
+	}
+	if over.FlipY {
+		o.FlipY = true
+	}
+	if over.RTL {
+		o.RTL = true
+	}
+	if over.Animation != "" {
+		o.Animation = over.Animation
+	}
+	if over.AnimationDuration != 0 {
+		o.AnimationDuration = over.AnimationDuration
+	}
+	if over.Nonce != "" {
+		o.Nonce = over.Nonce
+	}
+	return o
+}
+
+// parseAttrs converts the "attrs" template option into an attribute map.
+// It accepts any map with string keys, such as the map[string]any that dict
+// produces, and formats the values with fmt.Sprint.
+func parseAttrs(v any) (map[string]string, bool) {
+	if attrs, ok := v.(map[string]string); ok {
+		return attrs, true
+	}
+
+	m, ok := toStringMap(v)
+	if !ok {
+		return nil, false
+	}
+	attrs := make(map[string]string, len(m))
+	for k, v := range m {
+		attrs[k] = fmt.Sprint(v)
+	}
+	return attrs, true
+}
+
+// toStringMap converts a map with string keys of any element type into a
+// map[string]any.
+func toStringMap(v any) (map[string]any, bool) {
+	if m, ok := v.(map[string]any); ok {
+		return m, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]any, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+// toFloat converts any integer or floating point kind, or a string holding
+// a finite number, such as a query parameter, to a float64.
+func toFloat(v any) (float64, bool) {
+	var f float64
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		f = n
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(rv.Int()), true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return float64(rv.Uint()), true
+		case reflect.Float32, reflect.Float64:
+			f = rv.Float()
+		case reflect.String:
+			var err error
+			if f, err = strconv.ParseFloat(strings.TrimSpace(rv.String()), 64); err != nil {
+				return 0, false
+			}
+		default:
+			return 0, false
+		}
+	}
+	return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// toInt converts v to an int if it is a whole number, see toFloat.
+func toInt(v any) (int, bool) {
+	if n, ok := v.(int); ok {
+		return n, true
+	}
+	f, ok := toFloat(v)
+	if !ok || f != math.Trunc(f) || f > math.MaxInt32 || f < math.MinInt32 {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// toLength converts a number or a string to a valid width or height.
+func toLength(v any) (string, bool) {
+	var length string
+	if s, ok := toString(v); ok {
+		length = strings.TrimSpace(s)
+	} else if f, ok := toFloat(v); ok {
+		length = formatNumber(f)
+	}
+	return length, isCSSLength(length)
+}
+
+// toString converts any string kind, such as template.HTML or Name, to a
+// string.
+func toString(v any) (string, bool) {
+	if s, ok := v.(string); ok {
+		return s, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+	return rv.String(), true
+}
+
+// toBool converts a bool kind, or a string accepted by strconv.ParseBool
+// such as "true" or "1", to a bool.
+func toBool(v any) (bool, bool) {
+	if b, ok := v.(bool); ok {
+		return b, true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), true
+	case reflect.String:
+		b, err := strconv.ParseBool(strings.TrimSpace(rv.String()))
+		return b, err == nil
+	}
+	return false, false
+}
@@ -0,0 +1,256 @@
+package lucide
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseOptionsSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       map[string]any
+		wantWidth  string
+		wantHeight string
+		wantErr    bool
+	}{
+		{
+			name:       "int size",
+			opts:       map[string]any{"size": 32},
+			wantWidth:  "32",
+			wantHeight: "32",
+		},
+		{
+			name:       "numeric string size",
+			opts:       map[string]any{"size": "32"},
+			wantWidth:  "32",
+			wantHeight: "32",
+		},
+		{
+			name:       "css size",
+			opts:       map[string]any{"size": "1em"},
+			wantWidth:  "1em",
+			wantHeight: "1em",
+		},
+		{
+			name:       "width and height",
+			opts:       map[string]any{"width": "100%", "height": 16},
+			wantWidth:  "100%",
+			wantHeight: "16",
+		},
+		{
+			name:       "fractional width",
+			opts:       map[string]any{"width": 1.25},
+			wantWidth:  "1.25",
+			wantHeight: "24",
+		},
+		{
+			name:       "invalid unit",
+			opts:       map[string]any{"width": "10furlongs"},
+			wantWidth:  "24",
+			wantHeight: "24",
+			wantErr:    true,
+		},
+		{
+			name:       "injection attempt",
+			opts:       map[string]any{"size": `1" onload="alert(1)`},
+			wantWidth:  "24",
+			wantHeight: "24",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, errs := parseOptions(tt.opts)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Fatalf("parseOptions() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+
+			width, height := opts.lengths()
+			if width == "" {
+				width = strconv.Itoa(opts.size())
+			}
+			if height == "" {
+				height = strconv.Itoa(opts.size())
+			}
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("lengths() = %q, %q, want %q, %q", width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       Options
+		wantOption string
+	}{
+		{
+			name: "empty",
+			opts: Options{},
+		},
+		{
+			name: "valid lengths",
+			opts: Options{Width: "1.25rem", Height: ".5em"},
+		},
+		{
+			name:       "invalid width",
+			opts:       Options{Width: "wide"},
+			wantOption: "width",
+		},
+		{
+			name: "valid line styles",
+			opts: Options{StrokeLinecap: "square", StrokeLinejoin: "miter"},
+		},
+		{
+			name:       "invalid linecap",
+			opts:       Options{StrokeLinecap: "pointy"},
+			wantOption: "strokeLinecap",
+		},
+		{
+			name:       "invalid linejoin",
+			opts:       Options{StrokeLinejoin: "glue"},
+			wantOption: "strokeLinejoin",
+		},
+		{
+			name:       "invalid height",
+			opts:       Options{Height: "-1px"},
+			wantOption: "height",
+		},
+		{
+			name:       "negative size",
+			opts:       Options{Size: -3},
+			wantOption: "size",
+		},
+		{
+			name:       "negative stroke width",
+			opts:       Options{StrokeWidth: -1},
+			wantOption: "strokeWidth",
+		},
+		{
+			name:       "infinite stroke width",
+			opts:       Options{StrokeWidth: math.Inf(1)},
+			wantOption: "strokeWidth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantOption == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var invalid *ErrInvalidOption
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Validate() error = %v, want *ErrInvalidOption", err)
+			}
+			if invalid.Option != tt.wantOption {
+				t.Errorf("ErrInvalidOption.Option = %q, want %q", invalid.Option, tt.wantOption)
+			}
+		})
+	}
+}
+
+func TestSizing(t *testing.T) {
+	got := string(CircleX(Options{Width: "1em", Height: "2em"}))
+	if !strings.Contains(got, `width="1em" height="2em"`) {
+		t.Errorf("CircleX() = %v, want css width and height", got)
+	}
+
+	got = string(CircleX(Options{Size: -3, StrokeWidth: math.NaN()}))
+	if !strings.Contains(got, `width="24" height="24"`) || !strings.Contains(got, `stroke-width="2"`) {
+		t.Errorf("CircleX() = %v, want default size and stroke width", got)
+	}
+
+	got = string(CircleX(Options{OmitSize: true}))
+	if strings.Contains(got, ` width=`) || strings.Contains(got, ` height=`) {
+		t.Errorf("CircleX() = %v, want no width or height", got)
+	}
+
+	got = string(CircleX(Options{Width: `1" onload="x`}))
+	if !strings.Contains(got, `width="24"`) || strings.Contains(got, "onload") {
+		t.Errorf("CircleX() = %v, want invalid width ignored", got)
+	}
+
+	if _, err := Render("circle-x", Options{Width: "1parsec"}); err == nil {
+		t.Errorf("Render() with invalid width returned nil error")
+	}
+}
+
+type sizeString string
+
+func TestParseOptionsCoercion(t *testing.T) {
+	opts := Options{Size: 16, Class: "a"}
+
+	tests := []struct {
+		name    string
+		options []any
+		check   func(Options) bool
+	}{
+		{"int64 size", []any{map[string]any{"size": int64(32)}}, func(o Options) bool { return o.Size == 32 }},
+		{"uint8 size", []any{map[string]any{"size": uint8(32)}}, func(o Options) bool { return o.Size == 32 }},
+		{"json float size", []any{map[string]any{"size": 32.0}}, func(o Options) bool { return o.Size == 32 }},
+		{"float32 stroke width", []any{map[string]any{"strokeWidth": float32(1.5)}}, func(o Options) bool { return o.StrokeWidth == 1.5 }},
+		{"string stroke width", []any{map[string]any{"strokeWidth": " 1.5 "}}, func(o Options) bool { return o.StrokeWidth == 1.5 }},
+		{"named string size", []any{map[string]any{"size": sizeString("32")}}, func(o Options) bool { return o.Size == 32 }},
+		{"string bool", []any{map[string]any{"filled": "true"}}, func(o Options) bool { return o.Filled }},
+		{"typed map", []any{map[string]int{"size": 32}}, func(o Options) bool { return o.Size == 32 }},
+		{"string map", []any{map[string]string{"size": "32", "color": "red"}}, func(o Options) bool { return o.Size == 32 && o.Color == "red" }},
+		{"options value", []any{opts}, func(o Options) bool { return o.Size == 16 && o.Color == "currentColor" }},
+		{"options pointer", []any{&opts}, func(o Options) bool { return o.Size == 16 }},
+		{"nil options pointer", []any{(*Options)(nil)}, func(o Options) bool { return o.Size == 24 }},
+		{"later arguments win", []any{opts, map[string]any{"size": 48, "class": "b"}}, func(o Options) bool { return o.Size == 48 && o.Class == "a b" }},
+		{"transforms", []any{map[string]any{"rotate": "90", "flipX": "true", "flipY": "1", "rtl": true}}, func(o Options) bool { return o.Rotate == 90 && o.FlipX && o.FlipY && o.RTL }},
+		{"typed attrs", []any{map[string]any{"attrs": map[string]int{"data-n": 1}}}, func(o Options) bool { return o.Attrs["data-n"] == "1" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, errs := parseOptions(tt.options...)
+			if len(errs) > 0 {
+				t.Fatalf("parseOptions() errors = %v", errs)
+			}
+			if !tt.check(o) {
+				t.Errorf("parseOptions() = %+v", o)
+			}
+		})
+	}
+}
+
+func TestParseOptionsErrors(t *testing.T) {
+	_, errs := parseOptions(map[string]any{
+		"sise":        32,
+		"size":        "big",
+		"strokeWidth": -1,
+		"filled":      "yes please",
+		"class":       42,
+		"colour":      "red",
+	}, 42)
+
+	var got []string
+	for _, err := range errs {
+		var invalid *ErrInvalidOption
+		var unknown *ErrUnknownOption
+		switch {
+		case errors.As(err, &invalid):
+			got = append(got, "invalid "+invalid.Option)
+		case errors.As(err, &unknown):
+			got = append(got, "unknown "+unknown.Option)
+		default:
+			t.Errorf("unexpected error type %T", err)
+		}
+	}
+
+	want := "invalid size, invalid strokeWidth, invalid filled, invalid class, unknown colour, unknown sise, invalid options"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("parseOptions() errors = %v, want %v", strings.Join(got, ", "), want)
+	}
+}
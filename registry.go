@@ -0,0 +1,329 @@
+package lucide
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"iter"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// icon is a registered icon definition.
+type icon struct {
+	// name is the canonical icon name
+	name string
+
+	// paths is the inner SVG markup of the icon
+	paths string
+
+	// aliases are the alternative names of the icon
+	aliases []iconAlias
+
+	// tags, categories and contributors are the upstream icon metadata
+	tags         []string
+	categories   []string
+	contributors []string
+
+	// deprecated reports whether the icon itself is deprecated upstream
+	deprecated        bool
+	deprecationReason string
+
+	// mirrorRTL reports whether the icon is direction-sensitive and is
+	// mirrored when rendered with Options.RTL
+	mirrorRTL bool
+}
+
+// iconAlias is an alternative name of an icon.
+type iconAlias struct {
+	name              string
+	deprecated        bool
+	deprecationReason string
+}
+
+// render renders the icon with the first of opts, if any, through the
+// package level cache.
+func (ic *icon) render(opts []Options) template.HTML {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return renderCached(globalCache.Load(), ic, opt)
+}
+
+// writeTo writes the icon with the first of opts, if any, to w.
+// It reports an *ErrInvalidOption if the options fail Validate.
+func (ic *icon) writeTo(w io.Writer, opts []Options) error {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+		if err := opt.Validate(); err != nil {
+			return err
+		}
+	}
+	return writeSVG(w, ic.paths, ic.directed(opt))
+}
+
+// directed resolves opts.RTL for the icon: direction-sensitive icons are
+// flipped horizontally, others are left as they are.
+func (ic *icon) directed(opts Options) Options {
+	if opts.RTL && ic.mirrorRTL {
+		opts.FlipX = !opts.FlipX
+	}
+	opts.RTL = false
+	return opts
+}
+
+// iconRegistry maps icon names and aliases to their definitions.
+// This is populated by the generated icons.go file and Register.
+var iconRegistry = make(map[string]*icon)
+
+// registryMu guards iconRegistry and registryVersion.
+var registryMu sync.RWMutex
+
+// registryVersion is incremented on every change to iconRegistry, so
+// derived data such as the search index knows when to rebuild.
+var registryVersion uint64
+
+// IconInfo describes a registered icon name.
+type IconInfo struct {
+	// Name is the registered name
+	Name string
+
+	// Alias reports whether Name is an alias of another icon
+	Alias bool
+
+	// Target is the canonical icon name. It equals Name for canonical icons.
+	Target string
+
+	// Render renders the icon, like the individual icon functions
+	Render func(opts ...Options) template.HTML
+}
+
+// Names returns the canonical icon names in sorted order. Aliases are not
+// included, see Aliases.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(iconRegistry))
+	for name, ic := range iconRegistry {
+		if ic.name == name {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Aliases returns the alias icon names in sorted order.
+func Aliases() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var names []string
+	for name, ic := range iconRegistry {
+		if ic.name != name {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Exists reports whether name is a registered icon name or alias.
+func Exists(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	_, ok := iconRegistry[name]
+	return ok
+}
+
+// Count returns the number of canonical icons, not counting aliases.
+func Count() int {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	n := 0
+	for name, ic := range iconRegistry {
+		if ic.name == name {
+			n++
+		}
+	}
+	return n
+}
+
+// Icons returns an iterator over all registered names, including aliases,
+// in sorted order.
+//
+// Usage:
+//
+//	for info := range lucide.Icons() {
+//	    if !info.Alias {
+//	        fmt.Println(info.Name, info.Render(lucide.Options{Size: 16}))
+//	    }
+//	}
+func Icons() iter.Seq[IconInfo] {
+	return func(yield func(IconInfo) bool) {
+		registryMu.RLock()
+		icons := make(map[string]*icon, len(iconRegistry))
+		for name, ic := range iconRegistry {
+			icons[name] = ic
+		}
+		registryMu.RUnlock()
+
+		names := make([]string, 0, len(icons))
+		for name := range icons {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			ic := icons[name]
+			info := IconInfo{
+				Name:   name,
+				Alias:  ic.name != name,
+				Target: ic.name,
+				Render: func(opts ...Options) template.HTML {
+					return ic.render(opts)
+				},
+			}
+			if !yield(info) {
+				return
+			}
+		}
+	}
+}
+
+// findIcon returns the registered icon for name.
+func findIcon(name string) (*icon, bool) {
+	registryMu.RLock()
+	ic, ok := iconRegistry[name]
+	registryMu.RUnlock()
+	return ic, ok
+}
+
+// lookupIcon returns the registered icon for name, or an *ErrUnknownIcon.
+// Use findIcon where the error is discarded, since building it computes
+// suggestions.
+func lookupIcon(name string) (*icon, error) {
+	ic, ok := findIcon(name)
+	if !ok {
+		return nil, newErrUnknownIcon(name)
+	}
+	return ic, nil
+}
+
+// newErrUnknownIcon returns an *ErrUnknownIcon for name with suggestions
+// for similar names.
+func newErrUnknownIcon(name string) *ErrUnknownIcon {
+	return &ErrUnknownIcon{Name: name, Suggestions: Suggest(name)}
+}
+
+// registerIcon registers an icon and its aliases in the global registry.
+// This is called by generated code in icons.go.
+func registerIcon(ic *icon) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	iconRegistry[ic.name] = ic
+	for _, alias := range ic.aliases {
+		iconRegistry[alias.name] = ic
+	}
+	registryVersion++
+}
+
+// ErrIconExists is returned by Register when the name is already taken by a
+// built-in or previously registered icon.
+type ErrIconExists struct {
+	// Name is the icon name that was registered
+	Name string
+}
+
+func (e *ErrIconExists) Error() string {
+	return fmt.Sprintf("lucide: icon %q already exists", e.Name)
+}
+
+// iconNamePattern matches icon names: lowercase words separated by dashes,
+// with an optional "namespace:" prefix.
+var iconNamePattern = regexp.MustCompile(`^(?:[a-z0-9]+(?:-[a-z0-9]+)*:)?[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// whitespacePattern matches runs of whitespace in SVG markup.
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// Register adds a custom icon to the registry, so it can be rendered with
+// Icon, Render, FuncMap and WriteIcon using the same Options as the built-in
+// icons. innerSVG is the markup inside the <svg> element, drawn on the same
+// 24x24 viewBox as Lucide icons; a complete <svg> document is also accepted
+// and unwrapped. The markup is trusted and written as is.
+//
+// Names are lowercase words separated by dashes and may carry a namespace,
+// such as "acme:logo". Registering a name that already exists returns an
+// *ErrIconExists, use RegisterOverride to replace it. Register is safe for
+// concurrent use.
+//
+// Usage:
+//
+//	err := lucide.Register("acme:logo", `<path d="M4 20 12 4l8 16" />`)
+//
+// Then in your template:
+//
+//	{{ lucide "acme:logo" (dict "size" 32) }}
+func Register(name, innerSVG string) error {
+	return register(name, innerSVG, false)
+}
+
+// RegisterNamespace adds a custom icon named "namespace:name" to the
+// registry. See Register.
+func RegisterNamespace(namespace, name, innerSVG string) error {
+	return register(namespace+":"+name, innerSVG, false)
+}
+
+// RegisterOverride adds a custom icon to the registry, replacing any icon
+// already registered under name. Replacing a built-in icon affects lookups
+// by name only: the individual icon functions, such as CircleX, and the
+// aliases of the icon keep rendering the built-in version.
+func RegisterOverride(name, innerSVG string) error {
+	return register(name, innerSVG, true)
+}
+
+// register validates and registers a custom icon.
+func register(name, innerSVG string, override bool) error {
+	if !iconNamePattern.MatchString(name) {
+		return fmt.Errorf("lucide: invalid icon name %q", name)
+	}
+
+	paths := unwrapSVG(innerSVG)
+	if paths == "" {
+		return fmt.Errorf("lucide: icon %q has no SVG content", name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := iconRegistry[name]; ok && !override {
+		return &ErrIconExists{Name: name}
+	}
+
+	iconRegistry[name] = &icon{name: name, paths: paths}
+	registryVersion++
+	return nil
+}
+
+// unwrapSVG returns the content of an <svg> element with whitespace
+// collapsed, or the trimmed markup if it is not wrapped in <svg>.
+func unwrapSVG(svg string) string {
+	svg = strings.TrimSpace(svg)
+	if strings.HasPrefix(svg, "<svg") {
+		start := strings.Index(svg, ">")
+		end := strings.LastIndex(svg, "</svg>")
+		if start == -1 || end < start {
+			return ""
+		}
+		svg = strings.TrimSpace(svg[start+1 : end])
+	}
+	return whitespacePattern.ReplaceAllString(svg, " ")
+}
@@ -0,0 +1,256 @@
+package lucide
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// restoreRegistry restores the registry entries for names when the test
+// finishes.
+func restoreRegistry(t *testing.T, names ...string) {
+	t.Helper()
+
+	registryMu.RLock()
+	saved := make(map[string]*icon, len(names))
+	for _, name := range names {
+		saved[name] = iconRegistry[name]
+	}
+	registryMu.RUnlock()
+
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		for name, ic := range saved {
+			if ic == nil {
+				delete(iconRegistry, name)
+			} else {
+				iconRegistry[name] = ic
+			}
+		}
+		registryVersion++
+	})
+}
+
+func TestNames(t *testing.T) {
+	names := Names()
+	if len(names) != Count() {
+		t.Errorf("len(Names()) = %d, want Count() = %d", len(names), Count())
+	}
+	if !slices.IsSorted(names) {
+		t.Errorf("Names() is not sorted")
+	}
+	if !slices.Contains(names, "circle-x") {
+		t.Errorf("Names() is missing circle-x")
+	}
+	if slices.Contains(names, "alarm-check") {
+		t.Errorf("Names() contains the alias alarm-check")
+	}
+}
+
+func TestAliases(t *testing.T) {
+	aliases := Aliases()
+	if !slices.IsSorted(aliases) {
+		t.Errorf("Aliases() is not sorted")
+	}
+	if !slices.Contains(aliases, "alarm-check") {
+		t.Errorf("Aliases() is missing alarm-check")
+	}
+	if slices.Contains(aliases, "circle-x") {
+		t.Errorf("Aliases() contains the canonical name circle-x")
+	}
+}
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"circle-x", true},
+		{"alarm-check", true},
+		{"doesnt-exist", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := Exists(tt.name); got != tt.want {
+			t.Errorf("Exists(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIcons(t *testing.T) {
+	var count, aliases int
+	var prev string
+	for info := range Icons() {
+		if info.Name <= prev {
+			t.Fatalf("Icons() not sorted: %q after %q", info.Name, prev)
+		}
+		prev = info.Name
+
+		if info.Alias {
+			aliases++
+			if info.Target == info.Name {
+				t.Errorf("alias %q has itself as target", info.Name)
+			}
+		} else {
+			count++
+		}
+
+		if info.Name == "alarm-check" {
+			if !info.Alias || info.Target != "alarm-clock-check" {
+				t.Errorf("Icons() alarm-check = %+v, want alias of alarm-clock-check", info)
+			}
+			if got := info.Render(Options{Size: 16}); got != AlarmClockCheck(Options{Size: 16}) {
+				t.Errorf("Render() = %v, want AlarmClockCheck()", got)
+			}
+		}
+	}
+
+	if count != Count() {
+		t.Errorf("Icons() yielded %d canonical icons, want %d", count, Count())
+	}
+	if aliases != len(Aliases()) {
+		t.Errorf("Icons() yielded %d aliases, want %d", aliases, len(Aliases()))
+	}
+
+	n := 0
+	for range Icons() {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	restoreRegistry(t, "acme:logo", "acme:mark", "brand-x")
+
+	if err := Register("acme:logo", `<path d="M4 20 12 4l8 16" />`); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := RegisterNamespace("acme", "mark", `<svg xmlns="http://www.w3.org/2000/svg">
+  <circle cx="12" cy="12" r="4" />
+</svg>`); err != nil {
+		t.Fatalf("RegisterNamespace() error = %v", err)
+	}
+	if err := Register("brand-x", `<rect width="20" height="20" x="2" y="2" />`); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	got, err := Render("acme:logo", Options{Size: 32, Color: "red", Class: "logo"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{`width="32"`, `stroke="red"`, `class="logo"`, `<path d="M4 20 12 4l8 16" /></svg>`} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Render() = %v, want to contain %v", got, want)
+		}
+	}
+
+	if got := string(Icon("acme:mark")); !strings.Contains(got, `aria-hidden="true"><circle cx="12" cy="12" r="4" /></svg>`) {
+		t.Errorf("Icon() = %v, want unwrapped circle", got)
+	}
+
+	if !Exists("brand-x") || !slices.Contains(Names(), "brand-x") {
+		t.Errorf("registered icon brand-x is not listed")
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	restoreRegistry(t, "acme:logo")
+
+	tests := []struct {
+		name     string
+		iconName string
+		svg      string
+		exists   bool
+	}{
+		{name: "built-in collision", iconName: "circle-x", svg: "<path />", exists: true},
+		{name: "alias collision", iconName: "alarm-check", svg: "<path />", exists: true},
+		{name: "empty name", iconName: "", svg: "<path />"},
+		{name: "uppercase name", iconName: "Logo", svg: "<path />"},
+		{name: "empty namespace", iconName: ":logo", svg: "<path />"},
+		{name: "nested namespace", iconName: "a:b:c", svg: "<path />"},
+		{name: "markup in name", iconName: `x"><script>`, svg: "<path />"},
+		{name: "empty svg", iconName: "acme:logo", svg: "  "},
+		{name: "empty svg element", iconName: "acme:logo", svg: "<svg></svg>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Register(tt.iconName, tt.svg)
+			if err == nil {
+				t.Fatalf("Register() error = nil, want error")
+			}
+
+			var exists *ErrIconExists
+			if errors.As(err, &exists) != tt.exists {
+				t.Errorf("Register() error = %v, want *ErrIconExists: %v", err, tt.exists)
+			}
+		})
+	}
+
+	if Exists("acme:logo") {
+		t.Errorf("failed registration added acme:logo")
+	}
+}
+
+func TestRegisterOverride(t *testing.T) {
+	restoreRegistry(t, "circle-x", "acme:logo")
+
+	if err := RegisterOverride("circle-x", `<rect width="4" height="4" />`); err != nil {
+		t.Fatalf("RegisterOverride() error = %v", err)
+	}
+	if got := string(Icon("circle-x")); !strings.Contains(got, "<rect") {
+		t.Errorf("Icon() = %v, want overridden icon", got)
+	}
+	if got := string(CircleX()); strings.Contains(got, "<rect") {
+		t.Errorf("CircleX() = %v, want built-in icon", got)
+	}
+
+	if err := Register("acme:logo", "<path />"); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := RegisterOverride("acme:logo", "<circle />"); err != nil {
+		t.Fatalf("RegisterOverride() error = %v", err)
+	}
+	if got := string(Icon("acme:logo")); !strings.Contains(got, "<circle />") {
+		t.Errorf("Icon() = %v, want overridden custom icon", got)
+	}
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	names := make([]string, 16)
+	for i := range names {
+		names[i] = fmt.Sprintf("test:icon-%d", i)
+	}
+	restoreRegistry(t, names...)
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := Register(name, "<path />"); err != nil {
+				t.Errorf("Register(%q) error = %v", name, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			Icon("circle-x")
+			Exists(name)
+			Names()
+		}()
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		if !Exists(name) {
+			t.Errorf("Exists(%q) = false after Register", name)
+		}
+	}
+}
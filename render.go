@@ -0,0 +1,377 @@
+package lucide
+
+import (
+	"html/template"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Static parts of every rendered SVG.
+const (
+	svgOpen    = `<svg xmlns="http://www.w3.org/2000/svg"`
+	svgViewBox = ` viewBox="0 0 24 24"`
+	svgClose   = `</svg>`
+)
+
+// maxPooledBuffer is the largest buffer kept in bufPool, so a single huge
+// render does not pin memory.
+const maxPooledBuffer = 64 << 10
+
+// bufPool holds render buffers shared by buildSVG and writeSVG.
+var bufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 1024)
+		return &b
+	},
+}
+
+// WriteIcon writes an icon by name to w. It reports the same errors as
+// Render, and otherwise any error returned by w.
+//
+// Unlike Render it does not allocate a string for the result, which makes it
+// the cheapest way to stream many icons to an http.ResponseWriter or buffer.
+//
+// Usage:
+//
+//	err := lucide.WriteIcon(w, "circle-x", lucide.Options{Size: 32})
+func WriteIcon(w io.Writer, name string, opts Options) error {
+	ic, err := lookupIcon(name)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	return writeSVG(w, ic.paths, ic.directed(opts))
+}
+
+// buildSVG constructs an SVG string with the given parameters.
+// This is a helper function used by generated icon functions.
+func buildSVG(paths string, opts Options) template.HTML {
+	return buildSVGDefs("", paths, opts)
+}
+
+// buildSVGDefs is buildSVG with definitions, such as masks, written before
+// the paths. Unlike the paths, they are not repeated by the still copy of
+// animated icons, which would duplicate their ids.
+func buildSVGDefs(defs, paths string, opts Options) template.HTML {
+	bp := bufPool.Get().(*[]byte)
+	b := appendSVG((*bp)[:0], defs, paths, opts)
+	svg := template.HTML(b)
+	putBuffer(bp, b)
+	return svg
+}
+
+// writeSVG writes an SVG with the given parameters to w.
+// This is a helper function used by generated icon functions.
+func writeSVG(w io.Writer, paths string, opts Options) error {
+	bp := bufPool.Get().(*[]byte)
+	b := appendSVG((*bp)[:0], "", paths, opts)
+	_, err := w.Write(b)
+	putBuffer(bp, b)
+	return err
+}
+
+// putBuffer returns a render buffer to bufPool.
+func putBuffer(bp *[]byte, b []byte) {
+	if cap(b) > maxPooledBuffer {
+		return
+	}
+	*bp = b
+	bufPool.Put(bp)
+}
+
+// appendSVG appends an SVG with the given definitions, see buildSVGDefs,
+// and paths to b.
+//
+// Every user supplied value is validated or escaped for the attribute
+// context, since the result is used as trusted template.HTML. Invalid values
+// fall back to their defaults.
+func appendSVG(b []byte, defs, paths string, opts Options) []byte {
+	color := "currentColor"
+	if isCSSColor(opts.Color) {
+		color = opts.Color
+	}
+
+	size := opts.size()
+	width, height := opts.lengths()
+
+	b = append(b, svgOpen...)
+	if !opts.OmitSize {
+		b = append(b, ` width="`...)
+		b = appendLength(b, width, size)
+		b = append(b, `" height="`...)
+		b = appendLength(b, height, size)
+		b = append(b, '"')
+	}
+	b = append(b, svgViewBox...)
+
+	b = append(b, ` fill="`...)
+	switch {
+	case isCSSColor(opts.Fill):
+		b = appendEscaped(b, opts.Fill)
+	case opts.Filled:
+		b = appendEscaped(b, color)
+	default:
+		b = append(b, "none"...)
+	}
+
+	b = append(b, `" stroke="`...)
+	b = appendEscaped(b, color)
+	b = append(b, `" stroke-width="`...)
+	b = appendNumber(b, opts.strokeWidth())
+
+	b = append(b, `" stroke-linecap="`...)
+	if lineCaps[opts.StrokeLinecap] {
+		b = append(b, opts.StrokeLinecap...)
+	} else {
+		b = append(b, "round"...)
+	}
+
+	b = append(b, `" stroke-linejoin="`...)
+	if lineJoins[opts.StrokeLinejoin] {
+		b = append(b, opts.StrokeLinejoin...)
+	} else {
+		b = append(b, "round"...)
+	}
+	b = append(b, '"')
+
+	if opts.Class != "" {
+		b = append(b, ` class="`...)
+		b = appendEscaped(b, opts.Class)
+		b = append(b, '"')
+	}
+
+	b = appendAttrs(b, opts.Attrs)
+
+	title := titleID{}
+	if opts.Label != "" {
+		title = newTitleID(opts.Attrs["id"])
+	}
+	b = appendA11y(b, opts, title)
+	b = append(b, '>')
+
+	if opts.Label != "" {
+		b = append(b, `<title id="`...)
+		b = title.append(b)
+		b = append(b, `">`...)
+		b = appendEscaped(b, opts.Label)
+		b = append(b, `</title>`...)
+	}
+
+	b = append(b, defs...)
+	b = appendPaths(b, paths, opts)
+	b = append(b, svgClose...)
+
+	return b
+}
+
+// appendLength appends a CSS length, or size in pixels if length is empty.
+func appendLength(b []byte, length string, size int) []byte {
+	if length == "" {
+		return strconv.AppendInt(b, int64(size), 10)
+	}
+	return append(b, length...)
+}
+
+// appendNumber appends a float for use in an SVG attribute, rounded to three
+// decimals and without trailing zeros.
+func appendNumber(b []byte, f float64) []byte {
+	return strconv.AppendFloat(b, math.Round(f*1000)/1000, 'f', -1, 64)
+}
+
+// formatNumber formats a float for use in an SVG attribute, rounded to three
+// decimals and without trailing zeros.
+func formatNumber(f float64) string {
+	return string(appendNumber(nil, f))
+}
+
+// appendEscaped appends s escaped for an HTML attribute value or text node.
+// The escaping matches template.HTMLEscapeString.
+func appendEscaped(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			b = append(b, "&#34;"...)
+		case '\'':
+			b = append(b, "&#39;"...)
+		case '&':
+			b = append(b, "&amp;"...)
+		case '<':
+			b = append(b, "&lt;"...)
+		case '>':
+			b = append(b, "&gt;"...)
+		case 0:
+			b = append(b, "\uFFFD"...)
+		default:
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// appendPaths appends paths, wrapped in the groups needed for the Rotate,
+// FlipX, FlipY and Animation options.
+func appendPaths(b []byte, paths string, opts Options) []byte {
+	transformed := opts.transformed()
+	if transformed {
+		b = append(b, `<g transform="`...)
+		b = appendTransform(b, opts)
+		b = append(b, `">`...)
+	}
+
+	if _, ok := animations[opts.Animation]; ok {
+		b = appendAnimated(b, paths, opts)
+	} else {
+		b = append(b, paths...)
+	}
+
+	if transformed {
+		b = append(b, `</g>`...)
+	}
+	return b
+}
+
+// appendTransform appends the transform list for the Rotate, FlipX and
+// FlipY options, all around the 12,12 center of the viewBox. Flips are
+// applied before the rotation.
+func appendTransform(b []byte, opts Options) []byte {
+	if rotate := opts.rotation(); rotate != 0 {
+		b = append(b, "rotate("...)
+		b = appendNumber(b, rotate)
+		b = append(b, " 12 12)"...)
+		if opts.FlipX || opts.FlipY {
+			b = append(b, ' ')
+		}
+	}
+
+	switch {
+	case opts.FlipX && opts.FlipY:
+		b = append(b, "matrix(-1 0 0 -1 24 24)"...)
+	case opts.FlipX:
+		b = append(b, "matrix(-1 0 0 1 24 0)"...)
+	case opts.FlipY:
+		b = append(b, "matrix(1 0 0 -1 0 24)"...)
+	}
+
+	return b
+}
+
+// titleIDCounter generates unique <title> ids for labelled icons.
+var titleIDCounter atomic.Uint64
+
+// titleID identifies the <title> element of a labelled icon. It is derived
+// from the id attribute when set, or from titleIDCounter otherwise.
+type titleID struct {
+	base string
+	n    uint64
+}
+
+// newTitleID returns the title id for an icon with the given id attribute.
+func newTitleID(base string) titleID {
+	if base != "" {
+		return titleID{base: base}
+	}
+	return titleID{n: titleIDCounter.Add(1)}
+}
+
+// append appends the escaped id to b.
+func (t titleID) append(b []byte) []byte {
+	if t.base != "" {
+		b = appendEscaped(b, t.base)
+		return append(b, "-title"...)
+	}
+	b = append(b, "lucide-title-"...)
+	return strconv.AppendUint(b, t.n, 10)
+}
+
+// appendA11y appends the accessibility attributes for an icon. Attributes
+// already present in opts.Attrs are not repeated.
+func appendA11y(b []byte, opts Options, title titleID) []byte {
+	has := func(name string) bool {
+		_, ok := opts.Attrs[name]
+		return ok
+	}
+
+	if opts.Label == "" {
+		if has("aria-hidden") || has("aria-label") || has("aria-labelledby") || has("role") {
+			return b
+		}
+		return append(b, ` aria-hidden="true"`...)
+	}
+
+	if !has("role") {
+		b = append(b, ` role="img"`...)
+	}
+	if !has("aria-label") {
+		b = append(b, ` aria-label="`...)
+		b = appendEscaped(b, opts.Label)
+		b = append(b, '"')
+	}
+	if !has("aria-labelledby") {
+		b = append(b, ` aria-labelledby="`...)
+		b = title.append(b)
+		b = append(b, '"')
+	}
+
+	return b
+}
+
+// reservedAttrs are root attributes controlled by Options fields.
+// They cannot be set through Options.Attrs.
+var reservedAttrs = map[string]bool{
+	"xmlns":           true,
+	"width":           true,
+	"height":          true,
+	"viewbox":         true,
+	"fill":            true,
+	"stroke":          true,
+	"stroke-width":    true,
+	"stroke-linecap":  true,
+	"stroke-linejoin": true,
+	"class":           true,
+}
+
+// attrNamePattern matches attribute names that are safe to emit unquoted.
+var attrNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:.-]*$`)
+
+// isEventAttr reports whether name is an event handler attribute such as
+// onload. Escaping does not make their values safe, as they are scripts.
+func isEventAttr(name string) bool {
+	return len(name) >= 2 && strings.EqualFold(name[:2], "on")
+}
+
+// appendAttrs appends extra attributes in sorted order with escaped values.
+// Reserved, event handler and invalid attribute names are skipped.
+func appendAttrs(b []byte, attrs map[string]string) []byte {
+	if len(attrs) == 0 {
+		return b
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		if reservedAttrs[strings.ToLower(name)] || isEventAttr(name) || !attrNamePattern.MatchString(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		b = append(b, ' ')
+		b = append(b, name...)
+		b = append(b, `="`...)
+		b = appendEscaped(b, attrs[name])
+		b = append(b, '"')
+	}
+
+	return b
+}
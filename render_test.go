@@ -0,0 +1,256 @@
+package lucide
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteIcon(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Size: 32, Color: "red", Class: "icon", Label: "Close", Attrs: map[string]string{"id": "close"}}
+
+	if err := WriteIcon(&buf, "circle-x", opts); err != nil {
+		t.Fatalf("WriteIcon() error = %v", err)
+	}
+	if got, want := buf.String(), string(CircleX(opts)); got != want {
+		t.Errorf("WriteIcon() = %v, want %v", got, want)
+	}
+
+	var unknown *ErrUnknownIcon
+	if err := WriteIcon(&buf, "doesnt-exist", Options{}); !errors.As(err, &unknown) {
+		t.Errorf("WriteIcon() error = %v, want *ErrUnknownIcon", err)
+	}
+
+	var invalid *ErrInvalidOption
+	if err := WriteIcon(&buf, "circle-x", Options{Color: "nope"}); !errors.As(err, &invalid) {
+		t.Errorf("WriteIcon() error = %v, want *ErrInvalidOption", err)
+	}
+}
+
+func TestWriteIndividualIcon(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := WriteCircleX(&buf); err != nil {
+		t.Fatalf("WriteCircleX() error = %v", err)
+	}
+	if got, want := buf.String(), string(CircleX()); got != want {
+		t.Errorf("WriteCircleX() = %v, want %v", got, want)
+	}
+
+	buf.Reset()
+	if err := WriteAlarmCheck(&buf, Options{Size: 48}); err != nil {
+		t.Fatalf("WriteAlarmCheck() error = %v", err)
+	}
+	if got, want := buf.String(), string(AlarmClockCheck(Options{Size: 48})); got != want {
+		t.Errorf("WriteAlarmCheck() = %v, want %v", got, want)
+	}
+
+	if err := WriteCircleX(&buf, Options{Width: "wide"}); err == nil {
+		t.Errorf("WriteCircleX() with invalid width returned nil error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestWriteIconWriterError(t *testing.T) {
+	if err := WriteIcon(failingWriter{}, "circle-x", Options{}); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("WriteIcon() error = %v, want %v", err, io.ErrClosedPipe)
+	}
+}
+
+func TestAppendEscaped(t *testing.T) {
+	in := "a\"b'c&d<e>f\x00g"
+	want := "a&#34;b&#39;c&amp;d&lt;e&gt;f\uFFFDg"
+	if got := string(appendEscaped(nil, in)); got != want {
+		t.Errorf("appendEscaped() = %q, want %q", got, want)
+	}
+}
+
+func TestAppendAttrs(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs map[string]string
+		want  string
+	}{
+		{
+			name: "empty",
+			want: "",
+		},
+		{
+			name:  "sorted order",
+			attrs: map[string]string{"role": "img", "focusable": "false", "aria-label": "Close"},
+			want:  ` aria-label="Close" focusable="false" role="img"`,
+		},
+		{
+			name:  "escaped values",
+			attrs: map[string]string{"style": `color:red" onload="alert(1)`},
+			want:  ` style="color:red&#34; onload=&#34;alert(1)"`,
+		},
+		{
+			name:  "reserved and invalid names skipped",
+			attrs: map[string]string{"width": "100", "Class": "x", `x"y`: "1", "on load": "1", "transform": "scale(2)"},
+			want:  ` transform="scale(2)"`,
+		},
+		{
+			name:  "event handlers skipped",
+			attrs: map[string]string{"onload": "alert(document.domain)", "ONCLICK": "alert(1)", "onfocusin": "x", "data-on": "1"},
+			want:  ` data-on="1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(appendAttrs(nil, tt.attrs)); got != tt.want {
+				t.Errorf("appendAttrs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var benchOptions = Options{Size: 32, Color: "#3b82f6", StrokeWidth: 1.5, Class: "icon text-blue-500"}
+
+func BenchmarkIcon(b *testing.B) {
+	opts := map[string]any{"size": 32, "color": "#3b82f6", "strokeWidth": 1.5, "class": "icon text-blue-500"}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = Icon("circle-x", opts)
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = Render("circle-x", benchOptions)
+	}
+}
+
+func BenchmarkIndividualIcon(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = CircleX(benchOptions)
+	}
+}
+
+func BenchmarkWriteIcon(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = WriteIcon(io.Discard, "circle-x", benchOptions)
+	}
+}
+
+func BenchmarkWriteIndividualIcon(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = WriteCircleX(io.Discard, benchOptions)
+	}
+}
+
+// BenchmarkSprintfSVG formats the same SVG as BenchmarkRender the way
+// buildSVG did before it appended to a pooled buffer, for comparison.
+func BenchmarkSprintfSVG(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		opts := benchOptions
+		size := strconv.Itoa(opts.Size)
+		_ = template.HTML(fmt.Sprintf(
+			`<svg xmlns="http://www.w3.org/2000/svg"%s viewBox="0 0 24 24" fill="%s" stroke="%s" stroke-width="%s" stroke-linecap="%s" stroke-linejoin="%s"%s%s%s>%s%s</svg>`,
+			` width="`+size+`" height="`+size+`"`,
+			"none",
+			template.HTMLEscapeString(opts.Color),
+			strconv.FormatFloat(opts.StrokeWidth, 'f', -1, 64),
+			"round",
+			"round",
+			` class="`+template.HTMLEscapeString(opts.Class)+`"`,
+			"",
+			` aria-hidden="true"`,
+			"",
+			iconCircleX.paths,
+		))
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name string
+		icon string
+		opts Options
+		want string
+	}{
+		{"rotate", "chevron-down", Options{Rotate: 180}, `<g transform="rotate(180 12 12)">`},
+		{"rotate normalized", "chevron-down", Options{Rotate: 450}, `<g transform="rotate(90 12 12)">`},
+		{"flip x", "circle-x", Options{FlipX: true}, `<g transform="matrix(-1 0 0 1 24 0)">`},
+		{"flip y", "circle-x", Options{FlipY: true}, `<g transform="matrix(1 0 0 -1 0 24)">`},
+		{"flip both and rotate", "circle-x", Options{FlipX: true, FlipY: true, Rotate: -90}, `<g transform="rotate(-90 12 12) matrix(-1 0 0 -1 24 24)">`},
+		{"rtl mirrors arrows", "arrow-left", Options{RTL: true}, `<g transform="matrix(-1 0 0 1 24 0)">`},
+		{"rtl cancels flip", "arrow-left", Options{RTL: true, FlipX: true}, ""},
+		{"rtl keeps other icons", "circle-x", Options{RTL: true}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.icon, tt.opts)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if tt.want == "" {
+				if strings.Contains(string(got), "transform") {
+					t.Errorf("Render() = %v, want no transform", got)
+				}
+				return
+			}
+			if !strings.Contains(string(got), tt.want+lookupPaths(t, tt.icon)+"</g></svg>") {
+				t.Errorf("Render() = %v, want paths wrapped in %v", got, tt.want)
+			}
+
+			var buf bytes.Buffer
+			if err := WriteIcon(&buf, tt.icon, tt.opts); err != nil || buf.String() != string(got) {
+				t.Errorf("WriteIcon() = %v, %v, want %v", buf.String(), err, got)
+			}
+		})
+	}
+
+	var invalid *ErrInvalidOption
+	if _, err := Render("circle-x", Options{Rotate: math.NaN()}); !errors.As(err, &invalid) || invalid.Option != "rotate" {
+		t.Errorf("Render() error = %v, want *ErrInvalidOption for rotate", err)
+	}
+}
+
+func TestFuncMapRTL(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(FuncMap(&Config{RTL: true})).Parse(
+		`{{ lucide "arrow-left" }}|{{ lucide "circle-x" }}|{{ lucide "chevron-down" "rotate" 90 }}|{{ lucide "arrow-left" "rtl" false }}`,
+	))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := string(ArrowLeft(Options{FlipX: true})) + "|" + string(CircleX()) + "|" + string(ChevronDown(Options{Rotate: 90})) + "|" + string(ArrowLeft())
+	if buf.String() != want {
+		t.Errorf("Execute() = %v, want %v", buf.String(), want)
+	}
+
+	if meta, _ := Metadata("arrow-left"); !meta.MirrorRTL {
+		t.Errorf("Metadata(arrow-left).MirrorRTL = false, want true")
+	}
+}
+
+func lookupPaths(t *testing.T, name string) string {
+	t.Helper()
+	ic, ok := findIcon(name)
+	if !ok {
+		t.Fatalf("unknown icon %q", name)
+	}
+	return ic.paths
+}
@@ -0,0 +1,341 @@
+package lucide
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SearchResult is an icon matched by SearchIcons.
+type SearchResult struct {
+	// Name is the canonical icon name
+	Name string
+
+	// Score ranks the match between 0 and 1, higher is better
+	Score float64
+
+	// MatchedOn is the kind of term that matched: "name", "alias" or "tag"
+	MatchedOn string
+
+	// Term is the name, alias or tag that matched the query
+	Term string
+}
+
+// Match kinds and their base scores.
+const (
+	scoreExact     = 1.0
+	scorePrefix    = 0.8
+	scoreWord      = 0.7
+	scoreSubstring = 0.6
+	scoreFuzzy     = 0.5
+)
+
+// minFuzzySimilarity is the lowest similarity, between 0 and 1, for a fuzzy
+// match to be included.
+const minFuzzySimilarity = 0.6
+
+// searchField is the kind of term in the search index.
+type searchField uint8
+
+const (
+	fieldName searchField = iota
+	fieldAlias
+	fieldTag
+)
+
+func (f searchField) String() string {
+	switch f {
+	case fieldAlias:
+		return "alias"
+	case fieldTag:
+		return "tag"
+	default:
+		return "name"
+	}
+}
+
+// weight scales the score of a match on the field, so a match on the icon
+// name ranks above the same match on one of its tags.
+func (f searchField) weight() float64 {
+	switch f {
+	case fieldAlias:
+		return 0.95
+	case fieldTag:
+		return 0.85
+	default:
+		return 1
+	}
+}
+
+// termOwner is an icon that has a term in the search index.
+type termOwner struct {
+	icon  string
+	field searchField
+}
+
+// searchIndex indexes the names, aliases and tags of all registered icons.
+type searchIndex struct {
+	// version is the registryVersion the index was built from
+	version uint64
+
+	// terms are the unique normalized terms in sorted order
+	terms []string
+
+	// owners are the icons having each term, parallel to terms
+	owners [][]termOwner
+
+	// bigrams maps the padded bigrams of each term to the indices of the
+	// terms containing them
+	bigrams map[string][]int
+}
+
+var (
+	searchMu  sync.Mutex
+	searchIdx *searchIndex
+)
+
+// SearchIcons finds icons whose name, alias or upstream tag matches query, ranked
+// by exact, prefix, word, substring and fuzzy matches. At most limit results
+// are returned; a limit of zero or less returns all matches.
+//
+// The search index is built on first use and rebuilt after Register.
+//
+// Usage:
+//
+//	for _, r := range lucide.SearchIcons("arrow left", 10) {
+//	    fmt.Println(r.Name, r.Score, r.MatchedOn)
+//	}
+func SearchIcons(query string, limit int) []SearchResult {
+	q := normalizeTerm(query)
+	if q == "" {
+		return nil
+	}
+
+	idx := currentSearchIndex()
+	best := make(map[string]SearchResult)
+	add := func(term int, score float64) {
+		for _, owner := range idx.owners[term] {
+			s := score * owner.field.weight()
+			if r, ok := best[owner.icon]; ok && r.Score >= s {
+				continue
+			}
+			best[owner.icon] = SearchResult{
+				Name:      owner.icon,
+				Score:     s,
+				MatchedOn: owner.field.String(),
+				Term:      idx.terms[term],
+			}
+		}
+	}
+
+	// Exact and prefix matches are a contiguous range of the sorted terms.
+	start := sort.SearchStrings(idx.terms, q)
+	for i := start; i < len(idx.terms) && strings.HasPrefix(idx.terms[i], q); i++ {
+		if idx.terms[i] == q {
+			add(i, scoreExact)
+		} else {
+			add(i, scorePrefix)
+		}
+	}
+
+	// Substring and fuzzy matches are found through shared bigrams.
+	for term := range idx.candidates(q, 2) {
+		t := idx.terms[term]
+		if strings.HasPrefix(t, q) {
+			continue
+		}
+
+		if i := strings.Index(t, q); i > 0 {
+			if t[i-1] == '-' {
+				add(term, scoreWord)
+			} else {
+				add(term, scoreSubstring)
+			}
+			continue
+		}
+
+		// The length difference alone bounds the similarity from above.
+		longest := float64(max(len(q), len(t)))
+		if 1-float64(abs(len(q)-len(t)))/longest < minFuzzySimilarity {
+			continue
+		}
+		if sim := similarity(q, t); sim >= minFuzzySimilarity {
+			add(term, scoreFuzzy*sim)
+		}
+	}
+
+	results := make([]SearchResult, 0, len(best))
+	for _, r := range best {
+		results = append(results, r)
+	}
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		switch {
+		case a.Score != b.Score:
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		case len(a.Name) != len(b.Name):
+			return len(a.Name) - len(b.Name)
+		default:
+			return strings.Compare(a.Name, b.Name)
+		}
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// currentSearchIndex returns the search index, building it if the registry
+// changed since it was last built.
+func currentSearchIndex() *searchIndex {
+	registryMu.RLock()
+	version := registryVersion
+	registryMu.RUnlock()
+
+	searchMu.Lock()
+	defer searchMu.Unlock()
+
+	if searchIdx == nil || searchIdx.version != version {
+		searchIdx = buildSearchIndex()
+	}
+	return searchIdx
+}
+
+// buildSearchIndex indexes the current registry.
+func buildSearchIndex() *searchIndex {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	owners := make(map[string][]termOwner)
+	addTerm := func(term, icon string, field searchField) {
+		term = normalizeTerm(term)
+		if term == "" {
+			return
+		}
+		for _, o := range owners[term] {
+			if o.icon == icon && o.field <= field {
+				return
+			}
+		}
+		owners[term] = append(owners[term], termOwner{icon: icon, field: field})
+	}
+
+	for name, ic := range iconRegistry {
+		if ic.name != name {
+			continue
+		}
+		addTerm(name, name, fieldName)
+		for _, alias := range ic.aliases {
+			addTerm(alias.name, name, fieldAlias)
+		}
+		for _, tag := range ic.tags {
+			addTerm(tag, name, fieldTag)
+		}
+	}
+
+	idx := &searchIndex{
+		version: registryVersion,
+		terms:   make([]string, 0, len(owners)),
+		bigrams: make(map[string][]int),
+	}
+	for term := range owners {
+		idx.terms = append(idx.terms, term)
+	}
+	slices.Sort(idx.terms)
+
+	idx.owners = make([][]termOwner, len(idx.terms))
+	for i, term := range idx.terms {
+		idx.owners[i] = owners[term]
+		for _, g := range bigrams(term) {
+			if p := idx.bigrams[g]; len(p) == 0 || p[len(p)-1] != i {
+				idx.bigrams[g] = append(p, i)
+			}
+		}
+	}
+
+	return idx
+}
+
+// candidates returns the indices of the terms sharing at least 1/div of the
+// bigrams of q.
+func (idx *searchIndex) candidates(q string, div int) map[int]struct{} {
+	grams := bigrams(q)
+	hits := make(map[int]int)
+	for _, g := range grams {
+		for _, term := range idx.bigrams[g] {
+			hits[term]++
+		}
+	}
+
+	minHits := max(1, len(grams)/div)
+	terms := make(map[int]struct{})
+	for term, n := range hits {
+		if n >= minHits {
+			terms[term] = struct{}{}
+		}
+	}
+	return terms
+}
+
+// normalizeTerm lowercases s and joins its words with dashes, so "Arrow
+// Left" matches "arrow-left".
+func normalizeTerm(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '\t'
+	}), "-")
+}
+
+// bigrams returns the bigrams of s padded with a leading "^" and trailing
+// "$", so short words still share bigrams with their typos.
+func bigrams(s string) []string {
+	padded := "^" + s + "$"
+	grams := make([]string, 0, len(padded)-1)
+	for i := 0; i+2 <= len(padded); i++ {
+		grams = append(grams, padded[i:i+2])
+	}
+	return grams
+}
+
+// similarity returns 1 minus the edit distance of a and b relative to the
+// length of the longer one.
+func similarity(a, b string) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
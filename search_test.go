@@ -0,0 +1,127 @@
+package lucide
+
+import "testing"
+
+func TestSearchIcons(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantFirst string
+		matchedOn string
+	}{
+		{"menu", "menu", "name"},
+		{"MENU", "menu", "name"},
+		{"arrow left", "arrow-left", "name"},
+		{"arrow_left", "arrow-left", "name"},
+		{"mnu", "menu", "name"},
+		{"alarm-check", "alarm-clock-check", "alias"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results := SearchIcons(tt.query, 5)
+			if len(results) == 0 {
+				t.Fatalf("SearchIcons(%q) returned no results", tt.query)
+			}
+			if results[0].Name != tt.wantFirst {
+				t.Errorf("SearchIcons(%q)[0].Name = %q, want %q", tt.query, results[0].Name, tt.wantFirst)
+			}
+			if results[0].MatchedOn != tt.matchedOn {
+				t.Errorf("SearchIcons(%q)[0].MatchedOn = %q, want %q", tt.query, results[0].MatchedOn, tt.matchedOn)
+			}
+		})
+	}
+}
+
+func TestSearchIconsRanking(t *testing.T) {
+	results := SearchIcons("check", 0)
+	if len(results) < 3 {
+		t.Fatalf("SearchIcons(%q) returned %d results, want at least 3", "check", len(results))
+	}
+
+	if results[0].Name != "check" || results[0].Score != scoreExact {
+		t.Errorf("first result = %+v, want exact match on check", results[0])
+	}
+
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score {
+			t.Fatalf("results not sorted by score at %d: %v > %v", i, results[i].Score, results[i-1].Score)
+		}
+	}
+
+	scores := make(map[string]float64)
+	for _, r := range results {
+		scores[r.Name] = r.Score
+	}
+	if scores["check-check"] <= scores["circle-check"] {
+		t.Errorf("prefix match check-check (%v) should outrank word match circle-check (%v)", scores["check-check"], scores["circle-check"])
+	}
+}
+
+func TestSearchIconsLimit(t *testing.T) {
+	if got := len(SearchIcons("arrow", 3)); got != 3 {
+		t.Errorf("len(SearchIcons(%q, 3)) = %d, want 3", "arrow", got)
+	}
+	if got := SearchIcons("   ", 3); got != nil {
+		t.Errorf("SearchIcons(blank) = %v, want nil", got)
+	}
+	if got := SearchIcons("zzzzqqqq", 3); len(got) != 0 {
+		t.Errorf("SearchIcons(%q) = %v, want no results", "zzzzqqqq", got)
+	}
+}
+
+func TestSearchIconsTags(t *testing.T) {
+	restoreRegistry(t, "test-tagged")
+	registerIcon(&icon{name: "test-tagged", tags: []string{"Unicorn Horn"}})
+
+	results := SearchIcons("unicorn", 1)
+	if len(results) != 1 || results[0].Name != "test-tagged" {
+		t.Fatalf("SearchIcons(%q) = %v, want test-tagged", "unicorn", results)
+	}
+	if results[0].MatchedOn != "tag" || results[0].Term != "unicorn-horn" {
+		t.Errorf("result = %+v, want prefix match on tag unicorn-horn", results[0])
+	}
+}
+
+func TestSearchIconsRebuildsAfterRegister(t *testing.T) {
+	restoreRegistry(t, "test-searchable")
+
+	if got := SearchIcons("test-searchable", 1); len(got) != 0 && got[0].Term == "test-searchable" {
+		t.Fatalf("SearchIcons found test-searchable before it was registered")
+	}
+
+	if err := Register("test-searchable", `<circle cx="12" cy="12" r="10" />`); err != nil {
+		t.Fatal(err)
+	}
+
+	got := SearchIcons("test-searchable", 1)
+	if len(got) != 1 || got[0].Name != "test-searchable" {
+		t.Errorf("SearchIcons after Register = %v, want test-searchable", got)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"menu", "menu", 0},
+		{"menu", "mnu", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkSearchIcons(b *testing.B) {
+	SearchIcons("warm", 1)
+
+	for b.Loop() {
+		SearchIcons("arow", 10)
+	}
+}
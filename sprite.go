@@ -0,0 +1,118 @@
+package lucide
+
+import (
+	"html/template"
+	"strings"
+	"sync"
+)
+
+// spriteIDPrefix prefixes the id of every <symbol> in a sprite sheet.
+const spriteIDPrefix = "lucide-"
+
+// SpriteCollector records the icons used while rendering a page so their
+// path data is emitted once as <symbol> definitions instead of being inlined
+// on every use. A collector is request-scoped: create a new one for every
+// page render. It is safe for concurrent use.
+//
+// Usage:
+//
+//	sprite := lucide.NewSpriteCollector()
+//	tmpl := template.Must(base.Clone())
+//	tmpl.Funcs(sprite.FuncMap())
+//	tmpl.Execute(w, data)
+//
+// Then in your template, with the sprite after the last use:
+//
+//	{{ lucideUse "trash" (dict "size" 16 "class" "text-red-500") }}
+//	{{ lucideSprite }}
+type SpriteCollector struct {
+	mu    sync.Mutex
+	icons []*icon
+	seen  map[*icon]bool
+}
+
+// NewSpriteCollector creates an empty sprite collector.
+func NewSpriteCollector() *SpriteCollector {
+	return &SpriteCollector{
+		seen: make(map[*icon]bool),
+	}
+}
+
+// Use renders a reference to an icon and records it for the sprite sheet.
+// It accepts the same name and options as Icon. Unknown icons render as an
+// empty string.
+//
+// Usage in templates:
+//
+//	{{ lucideUse "menu" }}
+//	{{ lucideUse "menu" (dict "size" 32 "color" "red" "class" "my-icon") }}
+//	{{ lucideUse .IconName }}
+func (c *SpriteCollector) Use(name any, options ...any) template.HTML {
+	opts, _ := parseOptions(options...)
+	svg, _ := c.Render(iconName(name), opts)
+	return svg
+}
+
+// Render renders a reference to an icon and records it for the sprite sheet.
+// It reports the same errors as the package level Render.
+func (c *SpriteCollector) Render(name string, opts ...Options) (template.HTML, error) {
+	ic, err := lookupIcon(name)
+	if err != nil {
+		return template.HTML(""), err
+	}
+
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+		if err := opt.Validate(); err != nil {
+			return template.HTML(""), err
+		}
+	}
+
+	c.mu.Lock()
+	if !c.seen[ic] {
+		c.seen[ic] = true
+		c.icons = append(c.icons, ic)
+	}
+	c.mu.Unlock()
+
+	href := template.HTMLEscapeString("#" + spriteIDPrefix + ic.name)
+	return buildSVG(`<use href="`+href+`" />`, ic.directed(opt)), nil
+}
+
+// Sprite renders a hidden SVG with a <symbol> for every icon used so far,
+// in order of first use. It returns an empty string if no icons were used.
+//
+// Usage in templates:
+//
+//	{{ lucideSprite }}
+func (c *SpriteCollector) Sprite() template.HTML {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.icons) == 0 {
+		return template.HTML("")
+	}
+
+	var b strings.Builder
+	b.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" style="display:none" aria-hidden="true">`)
+	for _, ic := range c.icons {
+		b.WriteString(`<symbol id="`)
+		b.WriteString(template.HTMLEscapeString(spriteIDPrefix + ic.name))
+		b.WriteString(`" viewBox="0 0 24 24">`)
+		b.WriteString(ic.paths)
+		b.WriteString(`</symbol>`)
+	}
+	b.WriteString(`</svg>`)
+
+	return template.HTML(b.String())
+}
+
+// FuncMap returns the "lucideUse" and "lucideSprite" template functions
+// bound to this collector.
+func (c *SpriteCollector) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"lucideUse":    c.Use,
+		"lucideSprite": c.Sprite,
+	}
+}
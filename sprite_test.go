@@ -0,0 +1,94 @@
+package lucide
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestSpriteCollector(t *testing.T) {
+	c := NewSpriteCollector()
+
+	if got := c.Sprite(); got != "" {
+		t.Errorf("Sprite() on empty collector = %v, want empty", got)
+	}
+
+	use := string(c.Use("circle-x", map[string]any{"size": 16, "color": "red", "class": "icon"}))
+	for _, want := range []string{`width="16"`, `stroke="red"`, `class="icon"`, `<use href="#lucide-circle-x" />`} {
+		if !strings.Contains(use, want) {
+			t.Errorf("Use() = %v, want to contain %v", use, want)
+		}
+	}
+	if strings.Contains(use, "<circle") {
+		t.Errorf("Use() = %v, should not inline path data", use)
+	}
+
+	c.Use("circle-x")
+	c.Use("menu")
+	c.Use("doesnt-exist")
+
+	sprite := string(c.Sprite())
+	if n := strings.Count(sprite, "<symbol"); n != 2 {
+		t.Errorf("Sprite() has %d symbols, want 2: %v", n, sprite)
+	}
+	if !strings.Contains(sprite, `<symbol id="lucide-circle-x" viewBox="0 0 24 24"><circle`) {
+		t.Errorf("Sprite() = %v, want circle-x symbol", sprite)
+	}
+	if strings.Index(sprite, "lucide-circle-x") > strings.Index(sprite, "lucide-menu") {
+		t.Errorf("Sprite() = %v, want symbols in order of first use", sprite)
+	}
+}
+
+func TestSpriteCollectorAlias(t *testing.T) {
+	c := NewSpriteCollector()
+
+	got := string(c.Use("alarm-check"))
+	if !strings.Contains(got, `href="#lucide-alarm-clock-check"`) {
+		t.Errorf("Use() = %v, want reference to canonical symbol", got)
+	}
+
+	c.Use("alarm-clock-check")
+	if n := strings.Count(string(c.Sprite()), "<symbol"); n != 1 {
+		t.Errorf("Sprite() has %d symbols, want 1", n)
+	}
+}
+
+func TestSpriteCollectorRender(t *testing.T) {
+	c := NewSpriteCollector()
+
+	_, err := c.Render("doesnt-exist")
+	var unknown *ErrUnknownIcon
+	if !errors.As(err, &unknown) {
+		t.Errorf("Render() error = %v, want *ErrUnknownIcon", err)
+	}
+
+	if _, err := c.Render("menu", Options{Width: "huge"}); err == nil {
+		t.Errorf("Render() with invalid width returned nil error")
+	}
+
+	if got := c.Sprite(); got != "" {
+		t.Errorf("Sprite() = %v, failed renders should not be recorded", got)
+	}
+}
+
+func TestSpriteCollectorFuncMap(t *testing.T) {
+	c := NewSpriteCollector()
+	tmpl := template.Must(template.New("test").Funcs(FuncMap()).Funcs(c.FuncMap()).Parse(
+		`{{ range . }}{{ lucideUse . (dict "size" 16) }}{{ end }}{{ lucideSprite }}`,
+	))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, []Name{NameMenu, NameMenu, "menu"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	got := buf.String()
+	if n := strings.Count(got, `<use href="#lucide-menu" />`); n != 3 {
+		t.Errorf("rendered %d uses, want 3", n)
+	}
+	if n := strings.Count(got, `<symbol id="lucide-menu"`); n != 1 {
+		t.Errorf("rendered %d symbols, want 1", n)
+	}
+}
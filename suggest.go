@@ -0,0 +1,91 @@
+package lucide
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxSuggestions is the number of names returned by Suggest.
+const maxSuggestions = 3
+
+// minSuggestionScore is the lowest score, between 0 and 1, for a name to be
+// suggested.
+const minSuggestionScore = 0.5
+
+// Suggest returns up to three registered icon names closest to name, best
+// first, e.g. after an upstream rename or a typo. Names are compared by edit
+// distance and shared words against icon names, aliases and tags, and the
+// canonical name of the matching icon is returned. It returns nil if nothing
+// is close enough.
+//
+// Usage:
+//
+//	lucide.Suggest("alarm-clock-chek") // ["alarm-clock-check", ...]
+func Suggest(name string) []string {
+	q := normalizeTerm(name)
+	if q == "" {
+		return nil
+	}
+
+	idx := currentSearchIndex()
+	qWords := strings.Split(q, "-")
+	best := make(map[string]float64)
+	for term := range idx.candidates(q, 3) {
+		t := idx.terms[term]
+		score := suggestionScore(q, qWords, t)
+		for _, owner := range idx.owners[term] {
+			s := score * owner.field.weight()
+			if s >= minSuggestionScore && s > best[owner.icon] {
+				best[owner.icon] = s
+			}
+		}
+	}
+
+	names := make([]string, 0, len(best))
+	for name := range best {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		switch {
+		case best[a] != best[b]:
+			if best[a] > best[b] {
+				return -1
+			}
+			return 1
+		case len(a) != len(b):
+			return len(a) - len(b)
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+
+	if len(names) > maxSuggestions {
+		names = names[:maxSuggestions]
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+// suggestionScore rates term as a replacement for q by edit distance,
+// averaged with the share of the words of q found in term when they have
+// any in common. This ranks reordered or extended names, such as
+// "alarm-clock-check" for "alarm-check", above short names that happen to
+// be a few edits away.
+func suggestionScore(q string, qWords []string, term string) float64 {
+	sim := similarity(q, term)
+
+	words := strings.Split(term, "-")
+	common := 0
+	for _, w := range qWords {
+		if slices.Contains(words, w) {
+			common++
+		}
+	}
+	if common == 0 {
+		return sim
+	}
+
+	return (sim + float64(common)/float64(len(qWords))) / 2
+}
@@ -0,0 +1,82 @@
+package lucide
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantFirst string
+	}{
+		{"alarm-clock-chek", "alarm-clock-check"},
+		{"alarm-check-clock", "alarm-clock-check"},
+		{"arrow-lft", "arrow-left"},
+		{"Arrow Lft", "arrow-left"},
+		{"chek", "check"},
+		{"x-circle", "circle-x"},
+		{"trash-3", "trash-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Suggest(tt.name)
+			if len(got) == 0 || got[0] != tt.wantFirst {
+				t.Errorf("Suggest(%q) = %v, want %q first", tt.name, got, tt.wantFirst)
+			}
+			if len(got) > maxSuggestions {
+				t.Errorf("Suggest(%q) returned %d names, want at most %d", tt.name, len(got), maxSuggestions)
+			}
+		})
+	}
+
+	for _, name := range []string{"", "zzzz"} {
+		if got := Suggest(name); got != nil {
+			t.Errorf("Suggest(%q) = %v, want nil", name, got)
+		}
+	}
+}
+
+func TestSuggestAliasesAndTags(t *testing.T) {
+	restoreRegistry(t, "test:rocket", "test:spaceship")
+	registerIcon(&icon{
+		name:    "test:rocket",
+		aliases: []iconAlias{{name: "test:spaceship"}},
+		tags:    []string{"launch"},
+	})
+
+	if got := Suggest("test:spaceshp"); !slices.Contains(got, "test:rocket") {
+		t.Errorf("Suggest(%q) = %v, want test:rocket via its alias", "test:spaceshp", got)
+	}
+	if got := Suggest("lanch"); !slices.Contains(got, "test:rocket") {
+		t.Errorf("Suggest(%q) = %v, want test:rocket via its tag", "lanch", got)
+	}
+}
+
+func TestErrUnknownIconSuggestions(t *testing.T) {
+	_, err := Render("arrow-lft")
+
+	var unknown *ErrUnknownIcon
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Render() error = %v, want *ErrUnknownIcon", err)
+	}
+	if len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "arrow-left" {
+		t.Errorf("Suggestions = %v, want arrow-left first", unknown.Suggestions)
+	}
+
+	tests := []struct {
+		err  *ErrUnknownIcon
+		want string
+	}{
+		{&ErrUnknownIcon{Name: "x"}, `lucide: unknown icon "x"`},
+		{&ErrUnknownIcon{Name: "x", Suggestions: []string{"a"}}, `lucide: unknown icon "x" (did you mean "a"?)`},
+		{&ErrUnknownIcon{Name: "x", Suggestions: []string{"a", "b"}}, `lucide: unknown icon "x" (did you mean "a", "b"?)`},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}